@@ -18,14 +18,24 @@ import (
 
 	"github.com/carterperez-dev/templates/go-backend/internal/backup"
 	"github.com/carterperez-dev/templates/go-backend/internal/cleanup"
+	"github.com/carterperez-dev/templates/go-backend/internal/cluster"
 	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/documents"
+	"github.com/carterperez-dev/templates/go-backend/internal/drift"
+	"github.com/carterperez-dev/templates/go-backend/internal/explain"
 	"github.com/carterperez-dev/templates/go-backend/internal/handler"
 	"github.com/carterperez-dev/templates/go-backend/internal/health"
+	"github.com/carterperez-dev/templates/go-backend/internal/indexes"
+	"github.com/carterperez-dev/templates/go-backend/internal/kpi"
 	"github.com/carterperez-dev/templates/go-backend/internal/metrics"
 	"github.com/carterperez-dev/templates/go-backend/internal/middleware"
 	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/operations"
+	"github.com/carterperez-dev/templates/go-backend/internal/profiler"
+	"github.com/carterperez-dev/templates/go-backend/internal/savedquery"
 	"github.com/carterperez-dev/templates/go-backend/internal/server"
 	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+	"github.com/carterperez-dev/templates/go-backend/internal/validator"
 	"github.com/carterperez-dev/templates/go-backend/internal/websocket"
 )
 
@@ -72,10 +82,13 @@ func run(configPath string) error {
 		return err
 	}
 	logger.Info("mongodb connected",
+		"cluster", cfg.Mongo.Name,
 		"database", cfg.Mongo.Database,
 		"max_pool_size", cfg.Mongo.MaxPoolSize,
 	)
 
+	clusterRegistry := mongodb.NewRegistry(mongoClient, cfg.Mongo, logger)
+
 	sqliteClient, err := sqlite.NewClient(cfg.SQLite)
 	if err != nil {
 		return err
@@ -84,35 +97,116 @@ func run(configPath string) error {
 		"path", cfg.SQLite.Path,
 	)
 
+	clusterRepo := sqlite.NewClusterRepository(sqliteClient)
+	clusterSvc := cluster.NewService(clusterRegistry, clusterRepo, cfg.Mongo, logger)
+	if err := clusterSvc.Bootstrap(ctx, cfg.Clusters); err != nil {
+		logger.Warn("failed to load clusters", "error", err)
+	}
+	clusterRegistry.StartHealthChecks(ctx, 30*time.Second)
+	clustersHandler := handler.NewClustersHandler(clusterSvc)
+
 	healthHandler := health.NewHandler(mongoClient, sqliteClient)
 
+	kpiEvaluator := mongodb.NewKPIRepository(mongoClient)
+	kpiRepo := sqlite.NewKPIRepository(sqliteClient)
+	kpiSvc := kpi.NewService(kpiEvaluator, kpiRepo, clusterRegistry, cfg.KPI, logger)
+	kpisHandler := handler.NewKPIsHandler(kpiSvc)
+
+	collectionsRepo := mongodb.NewCollectionsRepository(mongoClient)
+
+	explainRepo := mongodb.NewExplainRepository(mongoClient)
+	slowQueryRepo := sqlite.NewSlowQueryRepository(sqliteClient)
+	explainSvc := explain.NewService(explainRepo, slowQueryRepo)
+
 	metricsRepo := mongodb.NewMetricsRepository(mongoClient)
-	metricsSvc := metrics.NewService(metricsRepo, cfg.Mongo.Database)
-	metricsHandler := handler.NewMetricsHandler(metricsSvc)
+	metricsSvc := metrics.NewService(metricsRepo, collectionsRepo, kpiSvc, cfg.Mongo.Database)
+	metricsHandler := handler.NewMetricsHandler(metricsSvc, explainSvc)
+
+	profilerSvc := profiler.NewService(metricsRepo, slowQueryRepo, clusterRegistry, cfg.Profiler, logger)
+	profilerSessionRepo := sqlite.NewProfilerSessionRepository(sqliteClient)
+	profilerSessions := profiler.NewSessionManager(metricsRepo, profilerSessionRepo, profilerSvc, logger)
+	profilerHandler := handler.NewProfilerHandler(profilerSvc, profilerSessions, cfg.Mongo.Database)
 
 	backupRepo := sqlite.NewBackupRepository(sqliteClient)
-	backupExecutor := backup.NewExecutor(cfg.Backup, cfg.Mongo.URI)
+	backupExecutor := backup.NewExecutor(cfg.Backup)
 	backupScheduler := backup.NewScheduler(logger)
-	backupSvc := backup.NewService(backupExecutor, backupScheduler, backupRepo, cfg.Backup.RetentionDays, logger)
+	backupSvc := backup.NewService(backupExecutor, backupScheduler, backupRepo, clusterRegistry, cfg.Backup.RetentionDays, logger)
 	backupsHandler := handler.NewBackupsHandler(backupSvc, cfg.Mongo.Database)
 
-	collectionsRepo := mongodb.NewCollectionsRepository(mongoClient)
-	collectionsHandler := handler.NewCollectionsHandler(collectionsRepo, cfg.Mongo.Database)
+	auditRepo := sqlite.NewAuditRepository(sqliteClient)
+
+	indexesSvc := indexes.NewService(collectionsRepo)
+	indexManager := indexes.NewManager(collectionsRepo, auditRepo, logger)
+	indexesHandler := handler.NewIndexesHandler(indexesSvc, cfg.Mongo.Database)
+
+	documentsSvc := documents.NewService(collectionsRepo)
+	documentEditor := documents.NewEditor(collectionsRepo, auditRepo, cfg.Documents, logger)
+	documentJobRepo := sqlite.NewDocumentJobRepository(sqliteClient)
+	exporter := documents.NewExporter(collectionsRepo, documentJobRepo, cfg.Documents, logger)
+	exportsHandler := handler.NewExportsHandler(exporter)
+	importer := documents.NewImporter(collectionsRepo, auditRepo, cfg.Documents, logger)
+	aggregator := documents.NewAggregator(collectionsRepo, auditRepo, cfg.Documents, logger)
+	validatorSvc := validator.NewService(collectionsRepo, auditRepo, cfg.Documents, logger)
+	collectionsHandler := handler.NewCollectionsHandler(collectionsRepo, explainSvc, indexManager, documentsSvc, documentEditor, exporter, importer, aggregator, validatorSvc, cfg.Mongo.Database)
+
+	savedQueryRepo := sqlite.NewSavedQueryRepository(sqliteClient)
+	savedQuerySvc := savedquery.NewService(savedQueryRepo, documentsSvc, aggregator, clusterRegistry, logger)
+	savedQueriesHandler := handler.NewSavedQueriesHandler(savedQuerySvc, cfg.Mongo.Database)
+
+	auditHandler := handler.NewAuditHandler(auditRepo)
+
+	operationsRepo := mongodb.NewOperationsRepository(mongoClient)
+	operationsSvc := operations.NewService(operationsRepo, auditRepo, logger)
+	operationsHandler := handler.NewOperationsHandler(operationsSvc)
 
 	cleanupSvc := cleanup.NewService(mongoClient.Client(), cfg.Mongo.Database, 30, logger)
 
+	kpiSvc.Start(ctx)
+	logger.Info("kpi evaluator started", "interval", cfg.KPI.Interval)
+
+	profilerSvc.Start(ctx)
+	logger.Info("slow query collector started", "interval", cfg.Profiler.CollectInterval)
+
+	if err := profilerSessions.Resume(ctx); err != nil {
+		logger.Error("failed to resume profiler sessions", "error", err)
+	}
+
+	if err := exporter.Resume(ctx); err != nil {
+		logger.Error("failed to resume export jobs", "error", err)
+	}
+
 	wsHub := websocket.NewHub(logger)
 	go wsHub.Run(ctx)
 
 	wsHandler := websocket.NewHandler(wsHub, logger)
 
-	metricsGetter := func(ctx context.Context) (any, error) {
-		return metricsSvc.GetDashboardMetrics(ctx)
+	metricsGetter := func(ctx context.Context, sub websocket.Subscription) (any, error) {
+		client, err := clusterRegistry.Get(sub.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		return metricsSvc.GetDashboardMetrics(mongodb.WithClient(ctx, client), sub.Database)
 	}
 	broadcaster := websocket.NewMetricsBroadcaster(wsHub, metricsGetter, 2000, logger)
 	broadcaster.Start(ctx)
 	logger.Info("websocket broadcaster started", "interval_ms", 2000)
 
+	topGetter := func(ctx context.Context, sub websocket.Subscription) (any, error) {
+		client, err := clusterRegistry.Get(sub.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		return metricsSvc.SampleTop(mongodb.WithClient(ctx, client))
+	}
+	topBroadcaster := websocket.NewClusterBroadcaster(wsHub, "top", topGetter, 2000, logger)
+	topBroadcaster.Start(ctx)
+
+	snapshotRepo := sqlite.NewSchemaSnapshotRepository(sqliteClient)
+	driftSvc := drift.NewService(collectionsRepo, snapshotRepo, clusterRegistry, wsHub, cfg.SchemaDrift, logger)
+	schemaDriftHandler := handler.NewSchemaDriftHandler(driftSvc)
+	driftSvc.Start(ctx)
+	logger.Info("schema drift monitor started", "interval", cfg.SchemaDrift.Interval, "collections", len(cfg.SchemaDrift.Collections))
+
 	srv := server.New(server.Config{
 		ServerConfig:  cfg.Server,
 		HealthHandler: healthHandler,
@@ -125,11 +219,21 @@ func run(configPath string) error {
 	router.Use(middleware.Logger(logger))
 	router.Use(middleware.SecurityHeaders(cfg.App.Environment == "production"))
 	router.Use(middleware.CORS(cfg.CORS))
+	router.Use(middleware.ClusterSelector(clusterRegistry))
 
 	healthHandler.RegisterRoutes(router)
+	clustersHandler.RegisterRoutes(router)
 	metricsHandler.RegisterRoutes(router)
+	kpisHandler.RegisterRoutes(router)
+	profilerHandler.RegisterRoutes(router)
 	backupsHandler.RegisterRoutes(router)
 	collectionsHandler.RegisterRoutes(router)
+	indexesHandler.RegisterRoutes(router)
+	operationsHandler.RegisterRoutes(router)
+	auditHandler.RegisterRoutes(router)
+	exportsHandler.RegisterRoutes(router)
+	savedQueriesHandler.RegisterRoutes(router)
+	schemaDriftHandler.RegisterRoutes(router)
 	router.Handle("/ws", wsHandler)
 
 	backupSvc.StartScheduler()
@@ -179,7 +283,10 @@ func run(configPath string) error {
 	<-schedulerCtx.Done()
 	logger.Info("backup scheduler stopped")
 
-	if err := mongoClient.Close(shutdownCtx); err != nil {
+	profilerSessions.Shutdown()
+	exporter.Shutdown()
+
+	if err := clusterRegistry.Close(shutdownCtx); err != nil {
 		logger.Error("mongodb close error", "error", err)
 	}
 
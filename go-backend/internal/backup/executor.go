@@ -20,15 +20,13 @@ type Executor struct {
 	mongodumpPath    string
 	mongorestorePath string
 	outputDir        string
-	mongoURI         string
 }
 
-func NewExecutor(cfg config.BackupConfig, mongoURI string) *Executor {
+func NewExecutor(cfg config.BackupConfig) *Executor {
 	return &Executor{
 		mongodumpPath:    cfg.MongodumpPath,
 		mongorestorePath: cfg.MongorestorePath,
 		outputDir:        cfg.OutputDir,
-		mongoURI:         mongoURI,
 	}
 }
 
@@ -38,19 +36,19 @@ type BackupResult struct {
 	Duration  time.Duration
 }
 
-func (e *Executor) Execute(ctx context.Context, dbName string) (*BackupResult, error) {
+func (e *Executor) Execute(ctx context.Context, mongoURI, clusterName, dbName string) (*BackupResult, error) {
 	if err := os.MkdirAll(e.outputDir, 0755); err != nil {
 		return nil, fmt.Errorf("create output dir: %w", err)
 	}
 
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	filename := fmt.Sprintf("%s_%s.gz", dbName, timestamp)
+	filename := fmt.Sprintf("%s_%s_%s.gz", clusterName, dbName, timestamp)
 	outputPath := filepath.Join(e.outputDir, filename)
 
 	start := time.Now()
 
 	cmd := exec.CommandContext(ctx, e.mongodumpPath,
-		"--uri", e.mongoURI,
+		"--uri", mongoURI,
 		"--db", dbName,
 		"--archive="+outputPath,
 		"--gzip",
@@ -73,9 +71,9 @@ func (e *Executor) Execute(ctx context.Context, dbName string) (*BackupResult, e
 	}, nil
 }
 
-func (e *Executor) Restore(ctx context.Context, backupPath, dbName string) error {
+func (e *Executor) Restore(ctx context.Context, mongoURI, backupPath, dbName string) error {
 	cmd := exec.CommandContext(ctx, e.mongorestorePath,
-		"--uri", e.mongoURI,
+		"--uri", mongoURI,
 		"--db", dbName,
 		"--archive="+backupPath,
 		"--gzip",
@@ -13,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
 	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
 )
 
@@ -25,19 +26,25 @@ type backupRepository interface {
 	DeleteOlderThan(ctx context.Context, days int) (int64, error)
 }
 
+type clusterResolver interface {
+	Get(name string) (*mongodb.Client, error)
+}
+
 type Service struct {
 	executor      *Executor
 	scheduler     *Scheduler
 	repo          backupRepository
+	clusters      clusterResolver
 	retentionDays int
 	logger        *slog.Logger
 }
 
-func NewService(executor *Executor, scheduler *Scheduler, repo backupRepository, retentionDays int, logger *slog.Logger) *Service {
+func NewService(executor *Executor, scheduler *Scheduler, repo backupRepository, clusters clusterResolver, retentionDays int, logger *slog.Logger) *Service {
 	s := &Service{
 		executor:      executor,
 		scheduler:     scheduler,
 		repo:          repo,
+		clusters:      clusters,
 		retentionDays: retentionDays,
 		logger:        logger,
 	}
@@ -57,8 +64,17 @@ func (s *Service) runBackup(ctx context.Context, dbName string) error {
 }
 
 func (s *Service) createBackup(ctx context.Context, dbName, triggeredBy string) (*sqlite.Backup, error) {
+	cluster, ok := mongodb.ClientFromContext(ctx)
+	if !ok {
+		var err error
+		if cluster, err = s.clusters.Get(""); err != nil {
+			return nil, fmt.Errorf("resolve cluster: %w", err)
+		}
+	}
+
 	backup := &sqlite.Backup{
 		ID:           uuid.New().String(),
+		ClusterName:  cluster.Name(),
 		DatabaseName: dbName,
 		FilePath:     "",
 		SizeBytes:    0,
@@ -71,7 +87,7 @@ func (s *Service) createBackup(ctx context.Context, dbName, triggeredBy string)
 		return nil, fmt.Errorf("create backup record: %w", err)
 	}
 
-	result, err := s.executor.Execute(ctx, dbName)
+	result, err := s.executor.Execute(ctx, cluster.URI(), cluster.Name(), dbName)
 	if err != nil {
 		s.repo.UpdateStatus(ctx, backup.ID, "failed", "", 0, err.Error())
 		return nil, fmt.Errorf("execute backup: %w", err)
@@ -87,6 +103,7 @@ func (s *Service) createBackup(ctx context.Context, dbName, triggeredBy string)
 
 	s.logger.Info("backup completed",
 		"id", backup.ID,
+		"cluster", backup.ClusterName,
 		"database", dbName,
 		"size_bytes", result.SizeBytes,
 		"duration", result.Duration,
@@ -106,11 +123,20 @@ func (s *Service) RestoreBackup(ctx context.Context, backupID string) error {
 		return fmt.Errorf("backup not found")
 	}
 
-	if err := s.executor.Restore(ctx, backup.FilePath, backup.DatabaseName); err != nil {
+	cluster, err := s.clusters.Get(backup.ClusterName)
+	if err != nil {
+		return fmt.Errorf("resolve cluster %q: %w", backup.ClusterName, err)
+	}
+
+	if err := s.executor.Restore(ctx, cluster.URI(), backup.FilePath, backup.DatabaseName); err != nil {
 		return fmt.Errorf("restore backup: %w", err)
 	}
 
-	s.logger.Info("backup restored", "id", backupID, "database", backup.DatabaseName)
+	s.logger.Info("backup restored",
+		"id", backupID,
+		"cluster", cluster.Name(),
+		"database", backup.DatabaseName,
+	)
 	return nil
 }
 
@@ -0,0 +1,179 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+var clusterNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,62}$`)
+
+type clusterRepository interface {
+	Create(ctx context.Context, c *sqlite.Cluster) error
+	List(ctx context.Context) ([]*sqlite.Cluster, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type Service struct {
+	registry *mongodb.Registry
+	repo     clusterRepository
+	defaults config.MongoConfig
+	logger   *slog.Logger
+}
+
+func NewService(registry *mongodb.Registry, repo clusterRepository, defaults config.MongoConfig, logger *slog.Logger) *Service {
+	return &Service{
+		registry: registry,
+		repo:     repo,
+		defaults: defaults,
+		logger:   logger,
+	}
+}
+
+type CreateInput struct {
+	Name        string
+	URI         string
+	Database    string
+	MaxPoolSize uint64
+	MinPoolSize uint64
+}
+
+func (s *Service) Bootstrap(ctx context.Context, clusters []config.MongoConfig) error {
+	for _, cfg := range clusters {
+		if err := s.registry.Register(ctx, cfg, mongodb.ClusterSourceConfig); err != nil {
+			s.logger.Warn("failed to register configured cluster", "cluster", cfg.Name, "error", err)
+		}
+	}
+
+	stored, err := s.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list stored clusters: %w", err)
+	}
+
+	for _, c := range stored {
+		if err := s.registry.Register(ctx, s.toConfig(c), mongodb.ClusterSourceStored); err != nil {
+			s.logger.Warn("failed to register stored cluster", "cluster", c.Name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) List() []mongodb.ClusterStatus {
+	return s.registry.List()
+}
+
+func (s *Service) Get(name string) (*mongodb.ClusterStatus, error) {
+	status, err := s.registry.Status(name)
+	if errors.Is(err, mongodb.ErrClusterNotFound) {
+		return nil, core.NotFoundError("cluster")
+	}
+	return status, err
+}
+
+func (s *Service) CheckHealth(ctx context.Context, name string) (*mongodb.ClusterHealth, error) {
+	health, err := s.registry.CheckHealth(ctx, name)
+	if errors.Is(err, mongodb.ErrClusterNotFound) {
+		return nil, core.NotFoundError("cluster")
+	}
+	return health, err
+}
+
+func (s *Service) Create(ctx context.Context, input CreateInput) (*mongodb.ClusterStatus, error) {
+	if !clusterNamePattern.MatchString(input.Name) {
+		return nil, core.ValidationError("name must be 1-63 characters of letters, digits, '-' or '_'")
+	}
+	if input.URI == "" {
+		return nil, core.ValidationError("uri is required")
+	}
+
+	now := time.Now()
+	record := &sqlite.Cluster{
+		Name:             input.Name,
+		URI:              input.URI,
+		DatabaseName:     input.Database,
+		MaxPoolSize:      int64(input.MaxPoolSize),
+		MinPoolSize:      int64(input.MinPoolSize),
+		ConnectTimeoutMs: s.defaults.ConnectTimeout.Milliseconds(),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err := s.registry.Register(ctx, s.toConfig(record), mongodb.ClusterSourceStored)
+	if errors.Is(err, mongodb.ErrClusterExists) {
+		return nil, core.DuplicateError("cluster " + input.Name)
+	}
+	if err != nil {
+		return nil, core.NewAppError(err, "could not connect to cluster", http.StatusUnprocessableEntity, "CLUSTER_UNREACHABLE")
+	}
+
+	if err := s.repo.Create(ctx, record); err != nil {
+		_ = s.registry.Remove(ctx, input.Name)
+		return nil, fmt.Errorf("persist cluster: %w", err)
+	}
+
+	return s.registry.Status(input.Name)
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	source, err := s.registry.Source(name)
+	if errors.Is(err, mongodb.ErrClusterNotFound) {
+		return core.NotFoundError("cluster")
+	}
+	if err != nil {
+		return err
+	}
+	if source != mongodb.ClusterSourceStored {
+		return core.ForbiddenError("clusters defined in config cannot be removed through the API")
+	}
+
+	if err := s.repo.Delete(ctx, name); err != nil {
+		return fmt.Errorf("delete stored cluster: %w", err)
+	}
+
+	if err := s.registry.Remove(ctx, name); err != nil && !errors.Is(err, mongodb.ErrClusterNotFound) {
+		return fmt.Errorf("remove cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) toConfig(c *sqlite.Cluster) config.MongoConfig {
+	cfg := config.MongoConfig{
+		Name:           c.Name,
+		URI:            c.URI,
+		Database:       c.DatabaseName,
+		MaxPoolSize:    uint64(c.MaxPoolSize),
+		MinPoolSize:    uint64(c.MinPoolSize),
+		ConnectTimeout: time.Duration(c.ConnectTimeoutMs) * time.Millisecond,
+	}
+
+	if cfg.Database == "" {
+		cfg.Database = s.defaults.Database
+	}
+	if cfg.MaxPoolSize == 0 {
+		cfg.MaxPoolSize = s.defaults.MaxPoolSize
+	}
+	if cfg.MinPoolSize == 0 {
+		cfg.MinPoolSize = s.defaults.MinPoolSize
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = s.defaults.ConnectTimeout
+	}
+
+	return cfg
+}
@@ -16,14 +16,21 @@ import (
 	"github.com/knadh/koanf/v2"
 )
 
+const MaxSchemaDriftSampleSize = 10000
+
 type Config struct {
-	App    AppConfig    `koanf:"app"`
-	Server ServerConfig `koanf:"server"`
-	Mongo  MongoConfig  `koanf:"mongodb"`
-	SQLite SQLiteConfig `koanf:"sqlite"`
-	Backup BackupConfig `koanf:"backup"`
-	CORS   CORSConfig   `koanf:"cors"`
-	Log    LogConfig    `koanf:"log"`
+	App         AppConfig         `koanf:"app"`
+	Server      ServerConfig      `koanf:"server"`
+	Mongo       MongoConfig       `koanf:"mongodb"`
+	Clusters    []MongoConfig     `koanf:"clusters"`
+	SQLite      SQLiteConfig      `koanf:"sqlite"`
+	Backup      BackupConfig      `koanf:"backup"`
+	KPI         KPIConfig         `koanf:"kpi"`
+	Profiler    ProfilerConfig    `koanf:"profiler"`
+	Documents   DocumentsConfig   `koanf:"documents"`
+	SchemaDrift SchemaDriftConfig `koanf:"schema_drift"`
+	CORS        CORSConfig        `koanf:"cors"`
+	Log         LogConfig         `koanf:"log"`
 }
 
 type AppConfig struct {
@@ -42,6 +49,7 @@ type ServerConfig struct {
 }
 
 type MongoConfig struct {
+	Name           string        `koanf:"name"`
 	URI            string        `koanf:"uri"`
 	Database       string        `koanf:"database"`
 	MaxPoolSize    uint64        `koanf:"max_pool_size"`
@@ -60,6 +68,59 @@ type BackupConfig struct {
 	RetentionDays    int    `koanf:"retention_days"`
 }
 
+type KPIConfig struct {
+	Interval      time.Duration   `koanf:"interval"`
+	RetentionDays int             `koanf:"retention_days"`
+	Definitions   []KPIDefinition `koanf:"definitions"`
+}
+
+type KPIDefinition struct {
+	Name       string         `koanf:"name"`
+	Label      string         `koanf:"label"`
+	Cluster    string         `koanf:"cluster"`
+	Database   string         `koanf:"database"`
+	Collection string         `koanf:"collection"`
+	Type       string         `koanf:"type"`
+	Filter     string         `koanf:"filter"`
+	Pipeline   string         `koanf:"pipeline"`
+	Exclusions []KPIExclusion `koanf:"exclusions"`
+}
+
+type KPIExclusion struct {
+	Field  string `koanf:"field"`
+	Values []any  `koanf:"values"`
+}
+
+type ProfilerConfig struct {
+	CollectInterval time.Duration `koanf:"collect_interval"`
+	RetentionDays   int           `koanf:"retention_days"`
+	BatchSize       int           `koanf:"batch_size"`
+	Databases       []string      `koanf:"databases"`
+}
+
+type DocumentsConfig struct {
+	ReadOnly            bool   `koanf:"read_only"`
+	ExportDir           string `koanf:"export_dir"`
+	ExportRetentionDays int    `koanf:"export_retention_days"`
+	ImportMaxBytes      int64  `koanf:"import_max_bytes"`
+}
+
+type SchemaDriftConfig struct {
+	Interval          time.Duration       `koanf:"interval"`
+	SampleSize        int                 `koanf:"sample_size"`
+	RetentionDays     int                 `koanf:"retention_days"`
+	CoverageThreshold float64             `koanf:"coverage_threshold"`
+	MinCoverage       float64             `koanf:"min_coverage"`
+	WebhookURL        string              `koanf:"webhook_url"`
+	Collections       []SchemaDriftTarget `koanf:"collections"`
+}
+
+type SchemaDriftTarget struct {
+	Cluster    string `koanf:"cluster"`
+	Database   string `koanf:"database"`
+	Collection string `koanf:"collection"`
+}
+
 type CORSConfig struct {
 	AllowedOrigins   []string `koanf:"allowed_origins"`
 	AllowedMethods   []string `koanf:"allowed_methods"`
@@ -107,6 +168,8 @@ func Load(configPath string) (*Config, error) {
 			return
 		}
 
+		applyClusterDefaults(cfg)
+
 		if err := validate(cfg); err != nil {
 			loadErr = fmt.Errorf("validate config: %w", err)
 			return
@@ -140,6 +203,7 @@ func loadDefaults(k *koanf.Koanf) error {
 		"server.idle_timeout":     "120s",
 		"server.shutdown_timeout": "15s",
 
+		"mongodb.name":            "default",
 		"mongodb.uri":             "mongodb://localhost:27017",
 		"mongodb.database":        "admin",
 		"mongodb.max_pool_size":   100,
@@ -153,6 +217,24 @@ func loadDefaults(k *koanf.Koanf) error {
 		"backup.mongorestore_path": "mongorestore",
 		"backup.retention_days":    30,
 
+		"kpi.interval":       "60s",
+		"kpi.retention_days": 90,
+
+		"profiler.collect_interval": "10s",
+		"profiler.retention_days":   14,
+		"profiler.batch_size":       1000,
+
+		"documents.read_only":             false,
+		"documents.export_dir":            "./exports",
+		"documents.export_retention_days": 7,
+		"documents.import_max_bytes":      256 << 20,
+
+		"schema_drift.interval":           "6h",
+		"schema_drift.sample_size":        1000,
+		"schema_drift.retention_days":     90,
+		"schema_drift.coverage_threshold": 20,
+		"schema_drift.min_coverage":       1,
+
 		"cors.allowed_origins": []string{"http://localhost:5173"},
 		"cors.allowed_methods": []string{
 			"GET",
@@ -166,6 +248,7 @@ func loadDefaults(k *koanf.Koanf) error {
 			"Accept",
 			"Content-Type",
 			"X-Request-ID",
+			"X-Cluster",
 		},
 		"cors.allow_credentials": true,
 		"cors.max_age":           300,
@@ -184,20 +267,24 @@ func loadDefaults(k *koanf.Koanf) error {
 }
 
 var envKeyMap = map[string]string{
-	"MONGODB_URI":            "mongodb.uri",
-	"MONGODB_DATABASE":       "mongodb.database",
-	"MONGODB_MAX_POOL_SIZE":  "mongodb.max_pool_size",
-	"MONGODB_MIN_POOL_SIZE":  "mongodb.min_pool_size",
-	"MONGODB_CONNECT_TIMEOUT": "mongodb.connect_timeout",
-	"SQLITE_PATH":            "sqlite.path",
-	"BACKUP_OUTPUT_DIR":      "backup.output_dir",
-	"BACKUP_MONGODUMP_PATH":  "backup.mongodump_path",
-	"BACKUP_RETENTION_DAYS":  "backup.retention_days",
-	"ENVIRONMENT":            "app.environment",
-	"HOST":                   "server.host",
-	"PORT":                   "server.port",
-	"LOG_LEVEL":              "log.level",
-	"LOG_FORMAT":             "log.format",
+	"MONGODB_NAME":             "mongodb.name",
+	"MONGODB_URI":              "mongodb.uri",
+	"MONGODB_DATABASE":         "mongodb.database",
+	"MONGODB_MAX_POOL_SIZE":    "mongodb.max_pool_size",
+	"MONGODB_MIN_POOL_SIZE":    "mongodb.min_pool_size",
+	"MONGODB_CONNECT_TIMEOUT":  "mongodb.connect_timeout",
+	"SQLITE_PATH":              "sqlite.path",
+	"BACKUP_OUTPUT_DIR":        "backup.output_dir",
+	"BACKUP_MONGODUMP_PATH":    "backup.mongodump_path",
+	"BACKUP_RETENTION_DAYS":    "backup.retention_days",
+	"DOCUMENTS_READ_ONLY":      "documents.read_only",
+	"DOCUMENTS_EXPORT_DIR":     "documents.export_dir",
+	"SCHEMA_DRIFT_WEBHOOK_URL": "schema_drift.webhook_url",
+	"ENVIRONMENT":              "app.environment",
+	"HOST":                     "server.host",
+	"PORT":                     "server.port",
+	"LOG_LEVEL":                "log.level",
+	"LOG_FORMAT":               "log.format",
 }
 
 func envKeyReplacer(s string) string {
@@ -212,6 +299,70 @@ func validate(c *Config) error {
 		return fmt.Errorf("MONGODB_URI is required")
 	}
 
+	if c.Mongo.Name == "" {
+		return fmt.Errorf("mongodb.name is required")
+	}
+
+	seen := map[string]bool{c.Mongo.Name: true}
+	for _, cluster := range c.Clusters {
+		if cluster.Name == "" || cluster.URI == "" {
+			return fmt.Errorf("clusters entries require name and uri")
+		}
+		if seen[cluster.Name] {
+			return fmt.Errorf("duplicate cluster name %q", cluster.Name)
+		}
+		seen[cluster.Name] = true
+	}
+
+	kpiNames := make(map[string]bool)
+	for _, kpi := range c.KPI.Definitions {
+		if kpi.Name == "" || kpi.Collection == "" {
+			return fmt.Errorf("kpi definitions require name and collection")
+		}
+		if kpiNames[kpi.Name] {
+			return fmt.Errorf("duplicate kpi name %q", kpi.Name)
+		}
+		kpiNames[kpi.Name] = true
+	}
+
+	if c.KPI.Interval <= 0 {
+		return fmt.Errorf("kpi.interval must be positive")
+	}
+
+	if c.Profiler.CollectInterval <= 0 {
+		return fmt.Errorf("profiler.collect_interval must be positive")
+	}
+
+	if c.Profiler.BatchSize <= 0 {
+		return fmt.Errorf("profiler.batch_size must be positive")
+	}
+
+	if c.Documents.ExportDir == "" {
+		return fmt.Errorf("documents.export_dir is required")
+	}
+
+	if c.Documents.ImportMaxBytes <= 0 {
+		return fmt.Errorf("documents.import_max_bytes must be positive")
+	}
+
+	if c.SchemaDrift.Interval <= 0 {
+		return fmt.Errorf("schema_drift.interval must be positive")
+	}
+
+	if c.SchemaDrift.SampleSize <= 0 || c.SchemaDrift.SampleSize > MaxSchemaDriftSampleSize {
+		return fmt.Errorf("schema_drift.sample_size must be between 1 and %d", MaxSchemaDriftSampleSize)
+	}
+
+	if c.SchemaDrift.CoverageThreshold <= 0 {
+		return fmt.Errorf("schema_drift.coverage_threshold must be positive")
+	}
+
+	for _, target := range c.SchemaDrift.Collections {
+		if target.Collection == "" {
+			return fmt.Errorf("schema_drift collections require a collection")
+		}
+	}
+
 	if c.CORS.AllowCredentials {
 		for _, origin := range c.CORS.AllowedOrigins {
 			if origin == "*" {
@@ -233,6 +384,24 @@ func validate(c *Config) error {
 	return nil
 }
 
+func applyClusterDefaults(c *Config) {
+	for i := range c.Clusters {
+		cluster := &c.Clusters[i]
+		if cluster.Database == "" {
+			cluster.Database = c.Mongo.Database
+		}
+		if cluster.MaxPoolSize == 0 {
+			cluster.MaxPoolSize = c.Mongo.MaxPoolSize
+		}
+		if cluster.MinPoolSize == 0 {
+			cluster.MinPoolSize = c.Mongo.MinPoolSize
+		}
+		if cluster.ConnectTimeout <= 0 {
+			cluster.ConnectTimeout = c.Mongo.ConnectTimeout
+		}
+	}
+}
+
 func (c *Config) IsProduction() bool {
 	return c.App.Environment == "production"
 }
@@ -0,0 +1,354 @@
+/*
+AngelaMos | 2026
+aggregate.go
+*/
+
+package documents
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	ActionAggregate = "document.aggregate"
+
+	DefaultAggregateLimit   = 100
+	MaxAggregateLimit       = 5000
+	DefaultAggregateMaxTime = 30 * time.Second
+	MaxAggregateMaxTime     = 5 * time.Minute
+	DefaultPreviewLimit     = 20
+	MaxPreviewLimit         = 1000
+)
+
+var writeStages = map[string]bool{
+	"$out":   true,
+	"$merge": true,
+}
+
+var leadingStages = map[string]bool{
+	"$collStats":         true,
+	"$indexStats":        true,
+	"$geoNear":           true,
+	"$search":            true,
+	"$searchMeta":        true,
+	"$vectorSearch":      true,
+	"$listSearchIndexes": true,
+	"$planCacheStats":    true,
+	"$querySettings":     true,
+}
+
+type aggregateRunner interface {
+	AggregateDocuments(ctx context.Context, dbName, collName string, query mongodb.AggregateQuery) ([]bson.Raw, error)
+}
+
+type Aggregator struct {
+	repo     aggregateRunner
+	audit    auditRepository
+	readOnly bool
+	logger   *slog.Logger
+}
+
+func NewAggregator(repo aggregateRunner, audit auditRepository, cfg config.DocumentsConfig, logger *slog.Logger) *Aggregator {
+	return &Aggregator{
+		repo:     repo,
+		audit:    audit,
+		readOnly: cfg.ReadOnly,
+		logger:   logger,
+	}
+}
+
+type AggregateInput struct {
+	Pipeline     json.RawMessage
+	MaxTimeMS    int64
+	Limit        int
+	AllowWrites  bool
+	AllowDiskUse bool
+	PreviewLimit int
+	Format       string
+}
+
+type AggregateResult struct {
+	Collection string            `json:"collection"`
+	Documents  []json.RawMessage `json:"documents"`
+	Count      int               `json:"count"`
+	Truncated  bool              `json:"truncated"`
+	Writes     []string          `json:"writes,omitempty"`
+	DurationMs int64             `json:"duration_ms"`
+}
+
+type StagePreview struct {
+	Index      int               `json:"index"`
+	Operator   string            `json:"operator"`
+	Stage      json.RawMessage   `json:"stage"`
+	Documents  []json.RawMessage `json:"documents"`
+	Count      int               `json:"count"`
+	Truncated  bool              `json:"truncated"`
+	Skipped    bool              `json:"skipped,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	DurationMs int64             `json:"duration_ms"`
+}
+
+type AggregatePreview struct {
+	Collection string         `json:"collection"`
+	InputLimit int            `json:"input_limit"`
+	Stages     []StagePreview `json:"stages"`
+}
+
+type aggregateOptions struct {
+	pipeline mongo.Pipeline
+	writes   []string
+	maxTime  time.Duration
+	limit    int
+}
+
+func (a *Aggregator) Run(ctx context.Context, dbName, collName string, input AggregateInput, actor Actor) (*AggregateResult, error) {
+	opts, err := a.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.writes) > 0 {
+		if !input.AllowWrites {
+			return nil, core.ValidationError(fmt.Sprintf("pipeline contains write stage %s; set allow_writes to run it", opts.writes[0]))
+		}
+		if a.readOnly {
+			return nil, core.ForbiddenError("aggregation write stages are disabled in read-only mode")
+		}
+	}
+
+	start := time.Now()
+	docs, err := a.repo.AggregateDocuments(ctx, dbName, collName, mongodb.AggregateQuery{
+		Pipeline:     opts.pipeline,
+		MaxTime:      opts.maxTime,
+		AllowDiskUse: input.AllowDiskUse,
+		Limit:        opts.limit + 1,
+	})
+	err = queryError(err)
+	if len(opts.writes) > 0 {
+		a.record(ctx, dbName, collName, opts, actor, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AggregateResult{
+		Collection: collName,
+		Writes:     opts.writes,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if len(docs) > opts.limit {
+		docs = docs[:opts.limit]
+		result.Truncated = true
+	}
+	result.Documents = encodeDocuments(docs, input.Format)
+	result.Count = len(result.Documents)
+
+	return result, nil
+}
+
+func (a *Aggregator) Preview(ctx context.Context, dbName, collName string, input AggregateInput) (*AggregatePreview, error) {
+	opts, err := a.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	inputLimit := input.PreviewLimit
+	if inputLimit <= 0 {
+		inputLimit = DefaultPreviewLimit
+	}
+	if inputLimit > MaxPreviewLimit {
+		return nil, core.ValidationError(fmt.Sprintf("preview_limit cannot exceed %d", MaxPreviewLimit))
+	}
+
+	preview := &AggregatePreview{
+		Collection: collName,
+		InputLimit: inputLimit,
+		Stages:     make([]StagePreview, 0, len(opts.pipeline)),
+	}
+
+	failed := false
+	for i, stage := range opts.pipeline {
+		operator := stage[0].Key
+		sp := StagePreview{
+			Index:     i,
+			Operator:  operator,
+			Stage:     encodeStage(stage),
+			Documents: []json.RawMessage{},
+		}
+
+		if writeStages[operator] {
+			sp.Skipped = true
+			sp.Error = "write stages are not executed in preview"
+			preview.Stages = append(preview.Stages, sp)
+			continue
+		}
+		if failed {
+			sp.Skipped = true
+			sp.Error = "skipped after an earlier stage failed"
+			preview.Stages = append(preview.Stages, sp)
+			continue
+		}
+
+		start := time.Now()
+		docs, err := a.repo.AggregateDocuments(ctx, dbName, collName, mongodb.AggregateQuery{
+			Pipeline:     previewPipeline(opts.pipeline[:i+1], inputLimit),
+			MaxTime:      opts.maxTime,
+			AllowDiskUse: input.AllowDiskUse,
+			Limit:        inputLimit + 1,
+		})
+		sp.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			var appErr *core.AppError
+			if errors.As(queryError(err), &appErr) {
+				sp.Error = appErr.Message
+			} else {
+				sp.Error = err.Error()
+			}
+			failed = true
+			preview.Stages = append(preview.Stages, sp)
+			continue
+		}
+
+		if len(docs) > inputLimit {
+			docs = docs[:inputLimit]
+			sp.Truncated = true
+		}
+		sp.Documents = encodeDocuments(docs, input.Format)
+		sp.Count = len(sp.Documents)
+		preview.Stages = append(preview.Stages, sp)
+	}
+
+	return preview, nil
+}
+
+func (a *Aggregator) validate(input AggregateInput) (*aggregateOptions, error) {
+	pipeline, err := mongodb.ParseExtJSONPipeline(input.Pipeline)
+	if err != nil {
+		return nil, core.ValidationError("pipeline must be an extended JSON array of stages")
+	}
+	if len(pipeline) == 0 {
+		return nil, core.ValidationError("pipeline must contain at least one stage")
+	}
+
+	opts := &aggregateOptions{
+		pipeline: pipeline,
+		maxTime:  DefaultAggregateMaxTime,
+		limit:    input.Limit,
+	}
+
+	for i, stage := range pipeline {
+		if len(stage) != 1 || len(stage[0].Key) < 2 || stage[0].Key[0] != '$' {
+			return nil, core.ValidationError(fmt.Sprintf("stage %d must be a document with a single $ operator", i))
+		}
+		if writeStages[stage[0].Key] {
+			if i != len(pipeline)-1 {
+				return nil, core.ValidationError(fmt.Sprintf("%s must be the last stage in the pipeline", stage[0].Key))
+			}
+			opts.writes = append(opts.writes, stage[0].Key)
+		}
+	}
+
+	if input.MaxTimeMS < 0 {
+		return nil, core.ValidationError("max_time_ms cannot be negative")
+	}
+	if input.MaxTimeMS > 0 {
+		opts.maxTime = time.Duration(input.MaxTimeMS) * time.Millisecond
+	}
+	if opts.maxTime > MaxAggregateMaxTime {
+		return nil, core.ValidationError(fmt.Sprintf("max_time_ms cannot exceed %d", MaxAggregateMaxTime.Milliseconds()))
+	}
+
+	if opts.limit < 0 {
+		return nil, core.ValidationError("limit cannot be negative")
+	}
+	if opts.limit == 0 {
+		opts.limit = DefaultAggregateLimit
+	}
+	if opts.limit > MaxAggregateLimit {
+		return nil, core.ValidationError(fmt.Sprintf("limit cannot exceed %d", MaxAggregateLimit))
+	}
+
+	return opts, nil
+}
+
+func (a *Aggregator) record(ctx context.Context, dbName, collName string, opts *aggregateOptions, actor Actor, opErr error) {
+	stages := make([]json.RawMessage, 0, len(opts.pipeline))
+	for _, stage := range opts.pipeline {
+		stages = append(stages, encodeStage(stage))
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"collection": collName,
+		"pipeline":   stages,
+		"writes":     opts.writes,
+	})
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       ActionAggregate,
+		Resource:     "collection",
+		ResourceID:   dbName + "." + collName,
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: dbName,
+		Details:      sql.NullString{String: string(payload), Valid: len(payload) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if opErr != nil {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: opErr.Error(), Valid: true}
+	}
+
+	if err := a.audit.Create(ctx, entry); err != nil {
+		a.logger.Error("failed to write audit entry", "action", ActionAggregate, "error", err)
+	}
+}
+
+func previewPipeline(stages mongo.Pipeline, inputLimit int) mongo.Pipeline {
+	limit := bson.D{{Key: "$limit", Value: int64(inputLimit)}}
+
+	pipeline := make(mongo.Pipeline, 0, len(stages)+2)
+	if leadingStages[stages[0][0].Key] {
+		pipeline = append(pipeline, stages[0], limit)
+		pipeline = append(pipeline, stages[1:]...)
+	} else {
+		pipeline = append(pipeline, limit)
+		pipeline = append(pipeline, stages...)
+	}
+	return append(pipeline, bson.D{{Key: "$limit", Value: int64(inputLimit + 1)}})
+}
+
+func encodeStage(stage bson.D) json.RawMessage {
+	raw, err := bson.Marshal(stage)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return mongodb.EncodeExtJSON(raw, mongodb.ExtJSONCanonical)
+}
+
+func encodeDocuments(docs []bson.Raw, format string) []json.RawMessage {
+	out := make([]json.RawMessage, 0, len(docs))
+	for _, doc := range docs {
+		out = append(out, mongodb.EncodeExtJSON(doc, format))
+	}
+	return out
+}
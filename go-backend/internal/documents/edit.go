@@ -0,0 +1,300 @@
+/*
+AngelaMos | 2026
+edit.go
+*/
+
+package documents
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	ActionReplace = "document.replace"
+	ActionUpdate  = "document.update"
+	ActionDelete  = "document.delete"
+)
+
+type documentWriter interface {
+	GetDocument(ctx context.Context, dbName, collName string, id bson.RawValue) (bson.Raw, error)
+	ReplaceDocument(ctx context.Context, dbName, collName string, current bson.Raw, replacement bson.D) (bson.Raw, error)
+	UpdateDocument(ctx context.Context, dbName, collName string, current bson.Raw, update bson.D) (bson.Raw, error)
+	DeleteDocument(ctx context.Context, dbName, collName string, current bson.Raw) error
+}
+
+type auditRepository interface {
+	Create(ctx context.Context, e *sqlite.AuditEntry) error
+}
+
+type Editor struct {
+	repo     documentWriter
+	audit    auditRepository
+	readOnly bool
+	logger   *slog.Logger
+}
+
+func NewEditor(repo documentWriter, audit auditRepository, cfg config.DocumentsConfig, logger *slog.Logger) *Editor {
+	return &Editor{
+		repo:     repo,
+		audit:    audit,
+		readOnly: cfg.ReadOnly,
+		logger:   logger,
+	}
+}
+
+type Actor struct {
+	RequestID  string
+	RemoteAddr string
+}
+
+type Document struct {
+	ID       json.RawMessage `json:"_id"`
+	Document json.RawMessage `json:"document"`
+	Hash     string          `json:"hash"`
+	ReadOnly bool            `json:"read_only"`
+}
+
+type change struct {
+	action   string
+	dbName   string
+	collName string
+	id       bson.RawValue
+	before   bson.Raw
+	after    bson.Raw
+}
+
+func (e *Editor) Get(ctx context.Context, dbName, collName, id, format string) (*Document, error) {
+	docID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := e.load(ctx, dbName, collName, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.document(raw, format), nil
+}
+
+func (e *Editor) Replace(ctx context.Context, dbName, collName, id string, replacement json.RawMessage, hash, format string, actor Actor) (*Document, error) {
+	docID, current, err := e.prepare(ctx, dbName, collName, id, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := mongodb.ParseExtJSONDocument(replacement)
+	if err != nil || len(doc) == 0 {
+		return nil, core.ValidationError("document must be a non-empty extended JSON document")
+	}
+	for _, elem := range doc {
+		if strings.HasPrefix(elem.Key, "$") {
+			return nil, core.ValidationError("replacement document cannot contain update operators")
+		}
+		if elem.Key == "_id" && !sameID(elem.Value, docID) {
+			return nil, core.ValidationError("replacement document cannot change _id")
+		}
+	}
+
+	after, err := e.repo.ReplaceDocument(ctx, dbName, collName, current, doc)
+	err = writeError(err)
+	e.record(ctx, change{ActionReplace, dbName, collName, docID, current, after}, actor, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.document(after, format), nil
+}
+
+func (e *Editor) Update(ctx context.Context, dbName, collName, id string, update json.RawMessage, hash, format string, actor Actor) (*Document, error) {
+	docID, current, err := e.prepare(ctx, dbName, collName, id, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := mongodb.ParseExtJSONDocument(update)
+	if err != nil || len(doc) == 0 {
+		return nil, core.ValidationError("update must be a non-empty extended JSON document")
+	}
+	for _, elem := range doc {
+		if !strings.HasPrefix(elem.Key, "$") {
+			return nil, core.ValidationError(fmt.Sprintf("update field %s must be an update operator such as $set", elem.Key))
+		}
+	}
+
+	after, err := e.repo.UpdateDocument(ctx, dbName, collName, current, doc)
+	err = writeError(err)
+	e.record(ctx, change{ActionUpdate, dbName, collName, docID, current, after}, actor, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.document(after, format), nil
+}
+
+func (e *Editor) Delete(ctx context.Context, dbName, collName, id, hash string, actor Actor) error {
+	docID, current, err := e.prepare(ctx, dbName, collName, id, hash)
+	if err != nil {
+		return err
+	}
+
+	err = writeError(e.repo.DeleteDocument(ctx, dbName, collName, current))
+	e.record(ctx, change{ActionDelete, dbName, collName, docID, current, nil}, actor, err)
+	return err
+}
+
+func (e *Editor) prepare(ctx context.Context, dbName, collName, id, hash string) (bson.RawValue, bson.Raw, error) {
+	if e.readOnly {
+		return bson.RawValue{}, nil, core.ForbiddenError("document writes are disabled in read-only mode")
+	}
+	if hash == "" {
+		return bson.RawValue{}, nil, core.ValidationError("hash of the previously read document is required")
+	}
+
+	docID, err := parseID(id)
+	if err != nil {
+		return bson.RawValue{}, nil, err
+	}
+
+	current, err := e.load(ctx, dbName, collName, docID)
+	if err != nil {
+		return bson.RawValue{}, nil, err
+	}
+	if documentHash(current) != hash {
+		return bson.RawValue{}, nil, staleError()
+	}
+
+	return docID, current, nil
+}
+
+func (e *Editor) load(ctx context.Context, dbName, collName string, id bson.RawValue) (bson.Raw, error) {
+	raw, err := e.repo.GetDocument(ctx, dbName, collName, id)
+	if errors.Is(err, mongodb.ErrDocumentNotFound) {
+		return nil, core.NotFoundError("document")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (e *Editor) document(raw bson.Raw, format string) *Document {
+	return &Document{
+		ID:       mongodb.EncodeExtJSONValue(raw.Lookup("_id"), format),
+		Document: mongodb.EncodeExtJSON(raw, format),
+		Hash:     documentHash(raw),
+		ReadOnly: e.readOnly,
+	}
+}
+
+func (e *Editor) record(ctx context.Context, c change, actor Actor, opErr error) {
+	details := map[string]any{
+		"collection":  c.collName,
+		"document_id": mongodb.EncodeExtJSONValue(c.id, mongodb.ExtJSONCanonical),
+		"before":      mongodb.EncodeExtJSON(c.before, mongodb.ExtJSONCanonical),
+		"before_hash": documentHash(c.before),
+	}
+	if len(c.after) > 0 {
+		details["after"] = mongodb.EncodeExtJSON(c.after, mongodb.ExtJSONCanonical)
+		details["after_hash"] = documentHash(c.after)
+	}
+	payload, _ := json.Marshal(details)
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       c.action,
+		Resource:     "document",
+		ResourceID:   c.dbName + "." + c.collName + "." + string(mongodb.EncodeExtJSONValue(c.id, mongodb.ExtJSONRelaxed)),
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: c.dbName,
+		Details:      sql.NullString{String: string(payload), Valid: len(payload) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if opErr != nil {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: opErr.Error(), Valid: true}
+	}
+
+	if err := e.audit.Create(ctx, entry); err != nil {
+		e.logger.Error("failed to write audit entry", "action", c.action, "error", err)
+	}
+}
+
+func parseID(id string) (bson.RawValue, error) {
+	if id == "" {
+		return bson.RawValue{}, core.ValidationError("document id is required")
+	}
+
+	if v, err := mongodb.ParseExtJSONValue([]byte(id)); err == nil {
+		return v, nil
+	}
+
+	var value any = id
+	if oid, err := bson.ObjectIDFromHex(id); err == nil {
+		value = oid
+	}
+
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return bson.RawValue{}, core.ValidationError("document id is not valid")
+	}
+	return bson.RawValue{Type: t, Value: data}, nil
+}
+
+func sameID(value any, id bson.RawValue) bool {
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return false
+	}
+	return bson.RawValue{Type: t, Value: data}.Equal(id)
+}
+
+func documentHash(raw bson.Raw) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func staleError() error {
+	return core.NewAppError(
+		core.ErrConflict,
+		"document has changed since it was read; reload it and try again",
+		http.StatusConflict,
+		"CONFLICT",
+	)
+}
+
+func writeError(err error) error {
+	if errors.Is(err, mongodb.ErrDocumentNotFound) {
+		return staleError()
+	}
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		return core.ValidationError(writeErr.Error())
+	}
+	if err != nil {
+		return queryError(err)
+	}
+	return nil
+}
@@ -0,0 +1,559 @@
+/*
+AngelaMos | 2026
+export.go
+*/
+
+package documents
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	FormatBSON  = "bson"
+
+	JobKindExport = "export"
+
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+
+	csvHeaderSample = 1000
+	csvFlushEvery   = 1000
+)
+
+var exportFormats = map[string]struct {
+	contentType string
+	extension   string
+}{
+	FormatJSONL: {"application/x-ndjson", "jsonl"},
+	FormatCSV:   {"text/csv; charset=utf-8", "csv"},
+	FormatBSON:  {"application/bson", "bson"},
+}
+
+type documentStreamer interface {
+	StreamDocuments(ctx context.Context, dbName, collName string, query mongodb.DocumentQuery, fn func(doc bson.Raw) error) error
+}
+
+type jobRepository interface {
+	Create(ctx context.Context, j *sqlite.DocumentJob) error
+	Finish(ctx context.Context, id, status string, documentCount, sizeBytes int64, completedAt time.Time, errorMsg string) error
+	GetByID(ctx context.Context, id string) (*sqlite.DocumentJob, error)
+	ListRecent(ctx context.Context, kind string, limit int) ([]*sqlite.DocumentJob, error)
+	ListByStatus(ctx context.Context, status string) ([]*sqlite.DocumentJob, error)
+	ListCompletedBefore(ctx context.Context, before time.Time) ([]*sqlite.DocumentJob, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type Exporter struct {
+	repo      documentStreamer
+	jobs      jobRepository
+	dir       string
+	retention time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	logger    *slog.Logger
+}
+
+func NewExporter(repo documentStreamer, jobs jobRepository, cfg config.DocumentsConfig, logger *slog.Logger) *Exporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Exporter{
+		repo:      repo,
+		jobs:      jobs,
+		dir:       cfg.ExportDir,
+		retention: time.Duration(cfg.ExportRetentionDays) * 24 * time.Hour,
+		ctx:       ctx,
+		cancel:    cancel,
+		logger:    logger,
+	}
+}
+
+type ExportInput struct {
+	Format     string          `json:"format"`
+	ExtJSON    string          `json:"extjson,omitempty"`
+	Filter     json.RawMessage `json:"filter,omitempty"`
+	Sort       json.RawMessage `json:"sort,omitempty"`
+	Projection json.RawMessage `json:"projection,omitempty"`
+	Fields     []string        `json:"fields,omitempty"`
+	Limit      int64           `json:"limit,omitempty"`
+}
+
+type Export struct {
+	ContentType string
+	Filename    string
+
+	repo       documentStreamer
+	dbName     string
+	collName   string
+	input      ExportInput
+	query      mongodb.DocumentQuery
+	fullHeader bool
+}
+
+type Job struct {
+	ID            string          `json:"id"`
+	Kind          string          `json:"kind"`
+	Cluster       string          `json:"cluster"`
+	Database      string          `json:"database"`
+	Collection    string          `json:"collection"`
+	Format        string          `json:"format"`
+	Options       json.RawMessage `json:"options"`
+	Status        string          `json:"status"`
+	DocumentCount int64           `json:"document_count"`
+	SizeBytes     int64           `json:"size_bytes"`
+	StartedAt     time.Time       `json:"started_at"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+func (e *Exporter) Prepare(dbName, collName string, input ExportInput) (*Export, error) {
+	if input.Format == "" {
+		input.Format = FormatJSONL
+	}
+	format, ok := exportFormats[input.Format]
+	if !ok {
+		return nil, core.ValidationError("format must be jsonl, csv or bson")
+	}
+
+	if input.ExtJSON == "" {
+		input.ExtJSON = mongodb.ExtJSONRelaxed
+	}
+	if !mongodb.ValidExtJSONFormat(input.ExtJSON) {
+		return nil, core.ValidationError("extjson must be relaxed or canonical")
+	}
+
+	if input.Limit < 0 {
+		return nil, core.ValidationError("limit must not be negative")
+	}
+
+	var query mongodb.DocumentQuery
+	for name, src := range map[string]struct {
+		data json.RawMessage
+		dst  *bson.D
+	}{
+		"filter":     {input.Filter, &query.Filter},
+		"sort":       {input.Sort, &query.Sort},
+		"projection": {input.Projection, &query.Projection},
+	} {
+		doc, err := mongodb.ParseExtJSONDocument(src.data)
+		if err != nil {
+			return nil, core.ValidationError(name + " must be a valid extended JSON document")
+		}
+		*src.dst = doc
+	}
+	query.Limit = input.Limit
+
+	return &Export{
+		ContentType: format.contentType,
+		Filename:    collName + "." + format.extension,
+		repo:        e.repo,
+		dbName:      dbName,
+		collName:    collName,
+		input:       input,
+		query:       query,
+	}, nil
+}
+
+func (x *Export) Stream(ctx context.Context, w io.Writer) (int64, error) {
+	var (
+		count int64
+		err   error
+	)
+
+	switch x.input.Format {
+	case FormatJSONL:
+		err = x.repo.StreamDocuments(ctx, x.dbName, x.collName, x.query, func(doc bson.Raw) error {
+			line := mongodb.EncodeExtJSON(doc, x.input.ExtJSON)
+			if line == nil {
+				return fmt.Errorf("encode document %d", count+1)
+			}
+			if _, err := w.Write(append(line, '\n')); err != nil {
+				return err
+			}
+			count++
+			return nil
+		})
+	case FormatBSON:
+		err = x.repo.StreamDocuments(ctx, x.dbName, x.collName, x.query, func(doc bson.Raw) error {
+			if _, err := w.Write(doc); err != nil {
+				return err
+			}
+			count++
+			return nil
+		})
+	case FormatCSV:
+		count, err = x.streamCSV(ctx, w)
+	}
+
+	if err != nil {
+		return count, queryError(err)
+	}
+	return count, nil
+}
+
+func (x *Export) streamCSV(ctx context.Context, w io.Writer) (int64, error) {
+	columns := x.input.Fields
+	if len(columns) == 0 {
+		var err error
+		if columns, err = x.sampleColumns(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(columns); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	row := make([]string, len(columns))
+	err := x.repo.StreamDocuments(ctx, x.dbName, x.collName, x.query, func(doc bson.Raw) error {
+		for i, column := range columns {
+			row[i] = ""
+			if value, err := doc.LookupErr(strings.Split(column, ".")...); err == nil {
+				row[i] = csvValue(value)
+			}
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+
+		count++
+		if count%csvFlushEvery == 0 {
+			out.Flush()
+			return out.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	out.Flush()
+	return count, out.Error()
+}
+
+func (x *Export) sampleColumns(ctx context.Context) ([]string, error) {
+	query := x.query
+	if !x.fullHeader && (query.Limit == 0 || query.Limit > csvHeaderSample) {
+		query.Limit = csvHeaderSample
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	err := x.repo.StreamDocuments(ctx, x.dbName, x.collName, query, func(doc bson.Raw) error {
+		mongodb.WalkDocument(doc, func(path string, value bson.RawValue) {
+			if value.Type == bson.TypeEmbeddedDocument || seen[path] || mongodb.IsArrayItemPath(path) {
+				return
+			}
+			seen[path] = true
+			columns = append(columns, path)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+func csvValue(value bson.RawValue) string {
+	switch value.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return ""
+	case bson.TypeString:
+		return value.StringValue()
+	case bson.TypeInt32:
+		return strconv.FormatInt(int64(value.Int32()), 10)
+	case bson.TypeInt64:
+		return strconv.FormatInt(value.Int64(), 10)
+	case bson.TypeDouble:
+		return strconv.FormatFloat(value.Double(), 'g', -1, 64)
+	case bson.TypeBoolean:
+		return strconv.FormatBool(value.Boolean())
+	case bson.TypeObjectID:
+		return value.ObjectID().Hex()
+	case bson.TypeDateTime:
+		return time.UnixMilli(value.DateTime()).UTC().Format(time.RFC3339Nano)
+	case bson.TypeDecimal128:
+		return value.Decimal128().String()
+	}
+	return string(mongodb.EncodeExtJSONValue(value, mongodb.ExtJSONRelaxed))
+}
+
+func (e *Exporter) StartJob(ctx context.Context, dbName, collName string, input ExportInput) (*Job, error) {
+	export, err := e.Prepare(dbName, collName, input)
+	if err != nil {
+		return nil, err
+	}
+	export.fullHeader = true
+
+	client, ok := mongodb.ClientFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no cluster client in context")
+	}
+
+	if err := os.MkdirAll(e.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create export directory: %w", err)
+	}
+
+	options, _ := json.Marshal(export.input)
+	id := uuid.New().String()
+	record := &sqlite.DocumentJob{
+		ID:             id,
+		Kind:           JobKindExport,
+		ClusterName:    client.Name(),
+		DatabaseName:   dbName,
+		CollectionName: collName,
+		Format:         export.input.Format,
+		Options:        string(options),
+		FilePath:       filepath.Join(e.dir, id+"."+exportFormats[export.input.Format].extension),
+		Status:         JobRunning,
+		StartedAt:      time.Now(),
+	}
+	if err := e.jobs.Create(ctx, record); err != nil {
+		return nil, err
+	}
+
+	e.wg.Add(1)
+	go e.run(mongodb.WithClient(e.ctx, client), export, record)
+
+	return toJob(record), nil
+}
+
+func (e *Exporter) run(ctx context.Context, export *Export, record *sqlite.DocumentJob) {
+	defer e.wg.Done()
+
+	count, size, runErr := writeExportFile(ctx, export, record.FilePath)
+
+	status := JobCompleted
+	errMsg := ""
+	if runErr != nil {
+		status = JobFailed
+		errMsg = runErr.Error()
+		os.Remove(record.FilePath)
+	}
+
+	finishCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := e.jobs.Finish(finishCtx, record.ID, status, count, size, time.Now(), errMsg); err != nil {
+		e.logger.Error("failed to record export job", "id", record.ID, "error", err)
+	}
+
+	if runErr != nil {
+		e.logger.Error("export failed", "id", record.ID, "collection", record.CollectionName, "error", runErr)
+		return
+	}
+	e.logger.Info("export completed",
+		"id", record.ID,
+		"collection", record.CollectionName,
+		"format", record.Format,
+		"documents", count,
+		"size_bytes", size,
+		"duration", time.Since(record.StartedAt),
+	)
+
+	e.prune(finishCtx)
+}
+
+func writeExportFile(ctx context.Context, export *Export, path string) (int64, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return 0, 0, fmt.Errorf("create export file: %w", err)
+	}
+	defer file.Close()
+
+	buf := bufio.NewWriterSize(file, 256*1024)
+	count, err := export.Stream(ctx, buf)
+	if err != nil {
+		return count, 0, err
+	}
+	if err := buf.Flush(); err != nil {
+		return count, 0, fmt.Errorf("write export file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return count, 0, fmt.Errorf("stat export file: %w", err)
+	}
+	return count, info.Size(), nil
+}
+
+func (e *Exporter) GetJob(ctx context.Context, id string) (*Job, error) {
+	record, err := e.lookup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toJob(record), nil
+}
+
+func (e *Exporter) ListJobs(ctx context.Context, limit int) ([]Job, error) {
+	records, err := e.jobs.ListRecent(ctx, JobKindExport, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, *toJob(record))
+	}
+	return jobs, nil
+}
+
+func (e *Exporter) OpenJob(ctx context.Context, id string) (*Job, *os.File, error) {
+	record, err := e.lookup(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record.Status != JobCompleted {
+		return nil, nil, jobNotReady(record)
+	}
+
+	file, err := os.Open(record.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, core.NotFoundError("export file")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("open export file: %w", err)
+	}
+
+	return toJob(record), file, nil
+}
+
+func (e *Exporter) DeleteJob(ctx context.Context, id string) error {
+	record, err := e.lookup(ctx, id)
+	if err != nil {
+		return err
+	}
+	if record.Status == JobRunning {
+		return jobNotReady(record)
+	}
+
+	return e.remove(ctx, record)
+}
+
+func (e *Exporter) Resume(ctx context.Context) error {
+	running, err := e.jobs.ListByStatus(ctx, JobRunning)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range running {
+		if record.Kind != JobKindExport {
+			continue
+		}
+		os.Remove(record.FilePath)
+		if err := e.jobs.Finish(ctx, record.ID, JobFailed, 0, 0, time.Now(), "interrupted by server restart"); err != nil {
+			return err
+		}
+		e.logger.Warn("export job interrupted by restart", "id", record.ID)
+	}
+
+	e.prune(ctx)
+	return nil
+}
+
+func (e *Exporter) Shutdown() {
+	e.cancel()
+	e.wg.Wait()
+}
+
+func (e *Exporter) lookup(ctx context.Context, id string) (*sqlite.DocumentJob, error) {
+	record, err := e.jobs.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Kind != JobKindExport {
+		return nil, core.NotFoundError("export job")
+	}
+	return record, nil
+}
+
+func (e *Exporter) prune(ctx context.Context) {
+	if e.retention <= 0 {
+		return
+	}
+
+	expired, err := e.jobs.ListCompletedBefore(ctx, time.Now().Add(-e.retention))
+	if err != nil {
+		e.logger.Error("failed to list expired export jobs", "error", err)
+		return
+	}
+
+	for _, record := range expired {
+		if record.Kind != JobKindExport {
+			continue
+		}
+		if err := e.remove(ctx, record); err != nil {
+			e.logger.Error("failed to prune export job", "id", record.ID, "error", err)
+		}
+	}
+}
+
+func (e *Exporter) remove(ctx context.Context, record *sqlite.DocumentJob) error {
+	if record.FilePath != "" {
+		if err := os.Remove(record.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			e.logger.Warn("failed to delete export file", "path", record.FilePath, "error", err)
+		}
+	}
+	return e.jobs.Delete(ctx, record.ID)
+}
+
+func jobNotReady(record *sqlite.DocumentJob) error {
+	return core.NewAppError(
+		core.ErrConflict,
+		fmt.Sprintf("export job %s is %s", record.ID, record.Status),
+		http.StatusConflict,
+		"CONFLICT",
+	)
+}
+
+func toJob(record *sqlite.DocumentJob) *Job {
+	job := &Job{
+		ID:            record.ID,
+		Kind:          record.Kind,
+		Cluster:       record.ClusterName,
+		Database:      record.DatabaseName,
+		Collection:    record.CollectionName,
+		Format:        record.Format,
+		Options:       json.RawMessage(record.Options),
+		Status:        record.Status,
+		DocumentCount: record.DocumentCount,
+		SizeBytes:     record.SizeBytes,
+		StartedAt:     record.StartedAt,
+	}
+	if record.CompletedAt.Valid {
+		job.CompletedAt = &record.CompletedAt.Time
+	}
+	if record.ErrorMessage.Valid {
+		job.Error = record.ErrorMessage.String
+	}
+	return job
+}
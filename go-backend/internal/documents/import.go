@@ -0,0 +1,702 @@
+/*
+AngelaMos | 2026
+import.go
+*/
+
+package documents
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	FormatJSON = "json"
+
+	ImportInsert  = "insert"
+	ImportUpsert  = "upsert"
+	ImportReplace = "replace"
+
+	ActionImport = "document.import"
+
+	defaultImportBatch = 1000
+	maxImportBatch     = 10000
+	maxImportErrors    = 1000
+	maxImportLine      = 16 << 20
+)
+
+var csvTypes = map[string]bool{
+	"auto":     true,
+	"string":   true,
+	"int":      true,
+	"long":     true,
+	"double":   true,
+	"decimal":  true,
+	"bool":     true,
+	"date":     true,
+	"objectId": true,
+	"json":     true,
+}
+
+type bulkWriter interface {
+	BulkWriteDocuments(ctx context.Context, dbName, collName string, models []mongo.WriteModel) (*mongo.BulkWriteResult, error)
+}
+
+type Importer struct {
+	repo     bulkWriter
+	audit    auditRepository
+	readOnly bool
+	maxBytes int64
+	logger   *slog.Logger
+}
+
+func NewImporter(repo bulkWriter, audit auditRepository, cfg config.DocumentsConfig, logger *slog.Logger) *Importer {
+	return &Importer{
+		repo:     repo,
+		audit:    audit,
+		readOnly: cfg.ReadOnly,
+		maxBytes: cfg.ImportMaxBytes,
+		logger:   logger,
+	}
+}
+
+type ImportInput struct {
+	Format    string
+	Mode      string
+	Key       []string
+	DryRun    bool
+	BatchSize int
+	Infer     bool
+	Schema    map[string]string
+}
+
+type RowError struct {
+	Row   int64  `json:"row"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	Collection      string     `json:"collection"`
+	Format          string     `json:"format"`
+	Mode            string     `json:"mode"`
+	DryRun          bool       `json:"dry_run"`
+	Rows            int64      `json:"rows"`
+	Valid           int64      `json:"valid"`
+	Inserted        int64      `json:"inserted"`
+	Updated         int64      `json:"updated"`
+	Matched         int64      `json:"matched"`
+	Failed          int64      `json:"failed"`
+	Errors          []RowError `json:"errors"`
+	ErrorsTruncated bool       `json:"errors_truncated,omitempty"`
+	Aborted         string     `json:"aborted,omitempty"`
+	DurationMs      int64      `json:"duration_ms"`
+}
+
+type rowReader interface {
+	next() (bson.D, int64, error)
+}
+
+type rowError struct {
+	row int64
+	msg string
+}
+
+func (e *rowError) Error() string {
+	return e.msg
+}
+
+type importBatch struct {
+	models []mongo.WriteModel
+	rows   []int64
+}
+
+func (i *Importer) Import(ctx context.Context, dbName, collName string, body io.Reader, input ImportInput, actor Actor) (*ImportResult, error) {
+	if err := validateImport(&input); err != nil {
+		return nil, err
+	}
+	if i.readOnly && !input.DryRun {
+		return nil, core.ForbiddenError("imports are disabled in read-only mode")
+	}
+
+	limited := &limitedReader{r: body, limit: i.maxBytes}
+	reader, err := newRowReader(limited, input)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	result := &ImportResult{
+		Collection: collName,
+		Format:     input.Format,
+		Mode:       input.Mode,
+		DryRun:     input.DryRun,
+		Errors:     make([]RowError, 0),
+	}
+
+	batch := &importBatch{}
+	var writeErr error
+	for writeErr == nil {
+		doc, row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rErr *rowError
+		if errors.As(err, &rErr) {
+			result.Rows++
+			result.addError(rErr.row, rErr.msg)
+			continue
+		}
+		if err != nil {
+			result.Aborted = err.Error()
+			break
+		}
+		result.Rows++
+
+		model, err := buildModel(doc, input)
+		if err != nil {
+			result.addError(row, err.Error())
+			continue
+		}
+
+		batch.models = append(batch.models, model)
+		batch.rows = append(batch.rows, row)
+		if len(batch.models) >= input.BatchSize {
+			writeErr = i.flush(ctx, dbName, collName, batch, input.DryRun, result)
+		}
+	}
+	if writeErr == nil {
+		writeErr = i.flush(ctx, dbName, collName, batch, input.DryRun, result)
+	}
+	if writeErr != nil {
+		result.Aborted = writeErr.Error()
+	}
+
+	sort.SliceStable(result.Errors, func(a, b int) bool {
+		return result.Errors[a].Row < result.Errors[b].Row
+	})
+	result.DurationMs = time.Since(started).Milliseconds()
+
+	if !input.DryRun {
+		i.record(ctx, dbName, collName, input, result, actor)
+		i.logger.Info("import completed",
+			"database", dbName,
+			"collection", collName,
+			"format", input.Format,
+			"mode", input.Mode,
+			"rows", result.Rows,
+			"inserted", result.Inserted,
+			"updated", result.Updated,
+			"failed", result.Failed,
+		)
+	}
+
+	return result, nil
+}
+
+func (i *Importer) flush(ctx context.Context, dbName, collName string, batch *importBatch, dryRun bool, result *ImportResult) error {
+	if len(batch.models) == 0 {
+		return nil
+	}
+	defer func() {
+		batch.models = batch.models[:0]
+		batch.rows = batch.rows[:0]
+	}()
+
+	if dryRun {
+		result.Valid += int64(len(batch.models))
+		return nil
+	}
+
+	written, err := i.repo.BulkWriteDocuments(ctx, dbName, collName, batch.models)
+	if written != nil {
+		result.Inserted += written.InsertedCount + written.UpsertedCount
+		result.Updated += written.ModifiedCount
+		result.Matched += written.MatchedCount
+	}
+
+	failed := 0
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) {
+		for _, we := range bulkErr.WriteErrors {
+			if we.Index >= 0 && we.Index < len(batch.rows) {
+				result.addError(batch.rows[we.Index], we.Message)
+				failed++
+			}
+		}
+		if bulkErr.WriteConcernError != nil {
+			return fmt.Errorf("write concern error: %s", bulkErr.WriteConcernError.Message)
+		}
+		err = nil
+	}
+	if err != nil {
+		for _, row := range batch.rows {
+			result.addError(row, "batch was not written")
+		}
+		return err
+	}
+
+	result.Valid += int64(len(batch.models) - failed)
+	return nil
+}
+
+func (i *Importer) record(ctx context.Context, dbName, collName string, input ImportInput, result *ImportResult, actor Actor) {
+	payload, _ := json.Marshal(map[string]any{
+		"collection": collName,
+		"format":     input.Format,
+		"mode":       input.Mode,
+		"key":        input.Key,
+		"rows":       result.Rows,
+		"inserted":   result.Inserted,
+		"updated":    result.Updated,
+		"failed":     result.Failed,
+	})
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       ActionImport,
+		Resource:     "collection",
+		ResourceID:   dbName + "." + collName,
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: dbName,
+		Details:      sql.NullString{String: string(payload), Valid: len(payload) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if result.Aborted != "" {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: result.Aborted, Valid: true}
+	}
+
+	if err := i.audit.Create(ctx, entry); err != nil {
+		i.logger.Error("failed to write audit entry", "action", ActionImport, "error", err)
+	}
+}
+
+func (r *ImportResult) addError(row int64, msg string) {
+	r.Failed++
+	if len(r.Errors) >= maxImportErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+	r.Errors = append(r.Errors, RowError{Row: row, Error: msg})
+}
+
+func validateImport(input *ImportInput) error {
+	switch input.Format {
+	case FormatJSONL, FormatJSON, FormatCSV:
+	default:
+		return core.ValidationError("format must be jsonl, json or csv")
+	}
+
+	switch input.Mode {
+	case "":
+		input.Mode = ImportInsert
+	case ImportInsert, ImportUpsert, ImportReplace:
+	default:
+		return core.ValidationError("mode must be insert, upsert or replace")
+	}
+
+	if input.Mode != ImportInsert && len(input.Key) == 0 {
+		input.Key = []string{"_id"}
+	}
+	for _, key := range input.Key {
+		if key == "" || strings.HasPrefix(key, "$") {
+			return core.ValidationError(fmt.Sprintf("invalid key field %q", key))
+		}
+	}
+
+	if input.BatchSize == 0 {
+		input.BatchSize = defaultImportBatch
+	}
+	if input.BatchSize < 1 || input.BatchSize > maxImportBatch {
+		return core.ValidationError(fmt.Sprintf("batch_size must be between 1 and %d", maxImportBatch))
+	}
+
+	for field, t := range input.Schema {
+		if !csvTypes[t] {
+			return core.ValidationError(fmt.Sprintf("unsupported type %q for field %s", t, field))
+		}
+	}
+
+	return nil
+}
+
+func buildModel(doc bson.D, input ImportInput) (mongo.WriteModel, error) {
+	if len(doc) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	for _, elem := range doc {
+		if strings.HasPrefix(elem.Key, "$") {
+			return nil, fmt.Errorf("top-level field %s cannot start with $", elem.Key)
+		}
+	}
+
+	if input.Mode == ImportInsert {
+		return mongo.NewInsertOneModel().SetDocument(doc), nil
+	}
+
+	filter := make(bson.D, 0, len(input.Key))
+	for _, key := range input.Key {
+		value, ok := lookupPath(doc, strings.Split(key, "."))
+		if !ok {
+			return nil, fmt.Errorf("key field %s is missing", key)
+		}
+		filter = append(filter, bson.E{Key: key, Value: value})
+	}
+
+	if input.Mode == ImportReplace {
+		return mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(doc).SetUpsert(true), nil
+	}
+
+	set := make(bson.D, 0, len(doc))
+	var id any
+	for _, elem := range doc {
+		if elem.Key == "_id" {
+			id = elem.Value
+			continue
+		}
+		set = append(set, elem)
+	}
+	if len(set) == 0 {
+		return nil, errors.New("document has no fields to update besides _id")
+	}
+
+	update := bson.D{{Key: "$set", Value: set}}
+	if id != nil {
+		update = append(update, bson.E{Key: "$setOnInsert", Value: bson.D{{Key: "_id", Value: id}}})
+	}
+	return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true), nil
+}
+
+func lookupPath(doc bson.D, path []string) (any, bool) {
+	for _, elem := range doc {
+		if elem.Key != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return elem.Value, true
+		}
+		nested, ok := elem.Value.(bson.D)
+		if !ok {
+			return nil, false
+		}
+		return lookupPath(nested, path[1:])
+	}
+	return nil, false
+}
+
+func newRowReader(r io.Reader, input ImportInput) (rowReader, error) {
+	switch input.Format {
+	case FormatJSON:
+		return newJSONArrayReader(r)
+	case FormatCSV:
+		return newCSVReader(r, input)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+	return &jsonlReader{scanner: scanner}, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int64
+}
+
+func (j *jsonlReader) next() (bson.D, int64, error) {
+	for j.scanner.Scan() {
+		j.line++
+		line := bytes.TrimSpace(j.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		doc, err := mongodb.ParseExtJSONDocument(line)
+		if err != nil {
+			return nil, j.line, &rowError{row: j.line, msg: "invalid extended JSON document"}
+		}
+		return doc, j.line, nil
+	}
+
+	if err := j.scanner.Err(); err != nil {
+		return nil, j.line, fmt.Errorf("read line %d: %w", j.line+1, err)
+	}
+	return nil, j.line, io.EOF
+}
+
+type jsonArrayReader struct {
+	decoder *json.Decoder
+	index   int64
+}
+
+func newJSONArrayReader(r io.Reader) (*jsonArrayReader, error) {
+	decoder := json.NewDecoder(r)
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, core.ValidationError("body must be a JSON array of documents")
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, core.ValidationError("body must be a JSON array of documents")
+	}
+	return &jsonArrayReader{decoder: decoder}, nil
+}
+
+func (j *jsonArrayReader) next() (bson.D, int64, error) {
+	if !j.decoder.More() {
+		return nil, j.index, io.EOF
+	}
+
+	j.index++
+	var raw json.RawMessage
+	if err := j.decoder.Decode(&raw); err != nil {
+		return nil, j.index, fmt.Errorf("decode element %d: %w", j.index, err)
+	}
+
+	doc, err := mongodb.ParseExtJSONDocument(raw)
+	if err != nil {
+		return nil, j.index, &rowError{row: j.index, msg: "invalid extended JSON document"}
+	}
+	return doc, j.index, nil
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	header  [][]string
+	columns []string
+	types   []string
+	row     int64
+}
+
+func newCSVReader(r io.Reader, input ImportInput) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, core.ValidationError("csv must start with a header row")
+	}
+
+	c := &csvReader{reader: reader}
+	for idx, column := range header {
+		if idx == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return nil, core.ValidationError(fmt.Sprintf("csv header column %d is empty", idx+1))
+		}
+
+		t := input.Schema[column]
+		if t == "" {
+			t = "string"
+			if input.Infer {
+				t = "auto"
+			}
+		}
+
+		c.columns = append(c.columns, column)
+		c.header = append(c.header, strings.Split(column, "."))
+		c.types = append(c.types, t)
+	}
+
+	return c, nil
+}
+
+func (c *csvReader) next() (bson.D, int64, error) {
+	record, err := c.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, c.row, io.EOF
+	}
+	c.row++
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, c.row, &rowError{row: c.row, msg: parseErr.Err.Error()}
+	}
+	if err != nil {
+		return nil, c.row, fmt.Errorf("read csv row %d: %w", c.row, err)
+	}
+
+	doc := bson.D{}
+	for idx, cell := range record {
+		if cell == "" && c.types[idx] != "string" {
+			continue
+		}
+
+		value, err := csvCell(cell, c.types[idx])
+		if err != nil {
+			return nil, c.row, &rowError{row: c.row, msg: fmt.Sprintf("%s: %v", c.columns[idx], err)}
+		}
+		if doc, err = setPath(doc, c.header[idx], value); err != nil {
+			return nil, c.row, &rowError{row: c.row, msg: fmt.Sprintf("%s: %v", c.columns[idx], err)}
+		}
+	}
+
+	return doc, c.row, nil
+}
+
+func csvCell(cell, t string) (any, error) {
+	switch t {
+	case "string":
+		return cell, nil
+	case "int":
+		v, err := strconv.ParseInt(cell, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an int", cell)
+		}
+		return int32(v), nil
+	case "long":
+		v, err := strconv.ParseInt(cell, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a long", cell)
+		}
+		return v, nil
+	case "double":
+		v, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a double", cell)
+		}
+		return v, nil
+	case "decimal":
+		v, err := bson.ParseDecimal128(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a decimal", cell)
+		}
+		return v, nil
+	case "bool":
+		v, err := strconv.ParseBool(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a bool", cell)
+		}
+		return v, nil
+	case "date":
+		v, err := time.Parse(time.RFC3339Nano, cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an RFC3339 date", cell)
+		}
+		return bson.NewDateTimeFromTime(v), nil
+	case "objectId":
+		v, err := bson.ObjectIDFromHex(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an objectId", cell)
+		}
+		return v, nil
+	case "json":
+		v, err := mongodb.ParseExtJSONValue([]byte(cell))
+		if err != nil {
+			return nil, fmt.Errorf("invalid extended JSON value")
+		}
+		return v, nil
+	}
+	return inferCell(cell), nil
+}
+
+func inferCell(cell string) any {
+	switch cell {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	digits := strings.TrimPrefix(cell, "-")
+	if digits != "" && digits[0] >= '0' && digits[0] <= '9' && (len(digits) == 1 || digits[0] != '0' || digits[1] == '.') {
+		if v, err := strconv.ParseInt(cell, 10, 64); err == nil {
+			if v >= -1<<31 && v < 1<<31 {
+				return int32(v)
+			}
+			return v
+		}
+		if v, err := strconv.ParseFloat(cell, 64); err == nil {
+			return v
+		}
+	}
+
+	if len(cell) == 24 {
+		if v, err := bson.ObjectIDFromHex(cell); err == nil {
+			return v
+		}
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, cell); err == nil {
+		return bson.NewDateTimeFromTime(t)
+	}
+
+	if cell[0] == '{' || cell[0] == '[' {
+		if v, err := mongodb.ParseExtJSONValue([]byte(cell)); err == nil {
+			return v
+		}
+	}
+
+	return cell
+}
+
+func setPath(doc bson.D, path []string, value any) (bson.D, error) {
+	for idx, elem := range doc {
+		if elem.Key != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return nil, errors.New("duplicate column")
+		}
+		nested, ok := elem.Value.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a document", path[0])
+		}
+		updated, err := setPath(nested, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		doc[idx].Value = updated
+		return doc, nil
+	}
+
+	if len(path) == 1 {
+		return append(doc, bson.E{Key: path[0], Value: value}), nil
+	}
+
+	nested, err := setPath(bson.D{}, path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	return append(doc, bson.E{Key: path[0], Value: nested}), nil
+}
+
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, fmt.Errorf("import exceeds the %d byte limit", l.limit)
+	}
+	return n, err
+}
@@ -0,0 +1,313 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package documents
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+type documentRepository interface {
+	FindDocuments(ctx context.Context, dbName, collName string, query mongodb.DocumentQuery) ([]bson.Raw, error)
+	CountDocuments(ctx context.Context, dbName, collName string, filter bson.D) (int64, error)
+}
+
+type Service struct {
+	repo documentRepository
+}
+
+func NewService(repo documentRepository) *Service {
+	return &Service{repo: repo}
+}
+
+type BrowseInput struct {
+	Filter     json.RawMessage
+	Sort       json.RawMessage
+	Projection json.RawMessage
+	Limit      int
+	After      string
+	Format     string
+}
+
+type Page struct {
+	Collection string            `json:"collection"`
+	Documents  []json.RawMessage `json:"documents"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+	Page       int               `json:"-"`
+	PageSize   int               `json:"-"`
+	Total      int               `json:"-"`
+}
+
+type sortKey struct {
+	field     string
+	direction int
+}
+
+type cursor struct {
+	Page   int             `bson:"p"`
+	Fields []string        `bson:"k"`
+	Values []bson.RawValue `bson:"v"`
+}
+
+func (s *Service) Browse(ctx context.Context, dbName, collName string, input BrowseInput) (*Page, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 0 || limit > MaxLimit {
+		return nil, core.ValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxLimit))
+	}
+
+	filter, err := mongodb.ParseExtJSONDocument(input.Filter)
+	if err != nil {
+		return nil, core.ValidationError("filter must be a valid extended JSON document")
+	}
+
+	keys, err := parseSort(input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	projection, err := mongodb.ParseExtJSONDocument(input.Projection)
+	if err != nil {
+		return nil, core.ValidationError("projection must be a valid extended JSON document")
+	}
+	for _, key := range keys {
+		if !projectionKeeps(projection, key.field) {
+			return nil, core.ValidationError(fmt.Sprintf("projection must include sort field %s", key.field))
+		}
+	}
+
+	page := 1
+	query := filter
+	if input.After != "" {
+		after, err := decodeCursor(input.After, keys)
+		if err != nil {
+			return nil, err
+		}
+		page = after.Page
+		query = bson.D{{Key: "$and", Value: bson.A{filter, keysetFilter(keys, after.Values)}}}
+	}
+
+	docs, err := s.repo.FindDocuments(ctx, dbName, collName, mongodb.DocumentQuery{
+		Filter:     query,
+		Sort:       sortDocument(keys),
+		Projection: projection,
+		Limit:      int64(limit) + 1,
+	})
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	total, err := s.repo.CountDocuments(ctx, dbName, collName, filter)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	result := &Page{
+		Collection: collName,
+		Documents:  make([]json.RawMessage, 0, len(docs)),
+		Page:       page,
+		PageSize:   limit,
+		Total:      int(total),
+	}
+
+	if len(docs) > limit {
+		docs = docs[:limit]
+		result.HasMore = true
+		next, err := encodeCursor(page+1, keys, docs[len(docs)-1])
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = next
+	}
+
+	for _, doc := range docs {
+		result.Documents = append(result.Documents, mongodb.EncodeExtJSON(doc, input.Format))
+	}
+
+	return result, nil
+}
+
+func parseSort(data json.RawMessage) ([]sortKey, error) {
+	doc, err := mongodb.ParseExtJSONDocument(data)
+	if err != nil {
+		return nil, core.ValidationError("sort must be a valid extended JSON document")
+	}
+
+	keys := make([]sortKey, 0, len(doc)+1)
+	hasID := false
+	for _, elem := range doc {
+		direction := 0
+		switch v := elem.Value.(type) {
+		case int32:
+			direction = int(v)
+		case int64:
+			direction = int(v)
+		case float64:
+			direction = int(v)
+		}
+		if direction != 1 && direction != -1 {
+			return nil, core.ValidationError(fmt.Sprintf("sort direction for %s must be 1 or -1", elem.Key))
+		}
+		if elem.Key == "_id" {
+			hasID = true
+		}
+		keys = append(keys, sortKey{field: elem.Key, direction: direction})
+	}
+
+	if !hasID {
+		keys = append(keys, sortKey{field: "_id", direction: 1})
+	}
+
+	return keys, nil
+}
+
+func sortDocument(keys []sortKey) bson.D {
+	doc := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		doc = append(doc, bson.E{Key: key.field, Value: key.direction})
+	}
+	return doc
+}
+
+func projectionKeeps(projection bson.D, field string) bool {
+	if len(projection) == 0 {
+		return true
+	}
+
+	inclusion := false
+	for _, elem := range projection {
+		if elem.Key != "_id" && projectionIncludes(elem.Value) {
+			inclusion = true
+			break
+		}
+	}
+
+	for _, elem := range projection {
+		if elem.Key == field || strings.HasPrefix(field, elem.Key+".") {
+			return projectionIncludes(elem.Value)
+		}
+	}
+
+	return !inclusion || field == "_id"
+}
+
+func projectionIncludes(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int32:
+		return v != 0
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	}
+	return true
+}
+
+func keysetFilter(keys []sortKey, values []bson.RawValue) bson.D {
+	clauses := bson.A{}
+	for i, key := range keys {
+		clause := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			clause = append(clause, bson.E{Key: keys[j].field, Value: values[j]})
+		}
+
+		value := values[i]
+		isNull := value.Type == bson.TypeNull || value.Type == bson.TypeUndefined
+		switch {
+		case key.direction == 1 && isNull:
+			clause = append(clause, bson.E{Key: key.field, Value: bson.D{{Key: "$ne", Value: nil}}})
+		case key.direction == 1:
+			clause = append(clause, bson.E{Key: key.field, Value: bson.D{{Key: "$gt", Value: value}}})
+		case isNull:
+			continue
+		default:
+			clause = append(clause, bson.E{Key: "$or", Value: bson.A{
+				bson.D{{Key: key.field, Value: bson.D{{Key: "$lt", Value: value}}}},
+				bson.D{{Key: key.field, Value: nil}},
+			}})
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) == 0 {
+		return bson.D{{Key: "_id", Value: bson.D{{Key: "$exists", Value: false}}}}
+	}
+	return bson.D{{Key: "$or", Value: clauses}}
+}
+
+func encodeCursor(page int, keys []sortKey, last bson.Raw) (string, error) {
+	c := cursor{Page: page}
+	for _, key := range keys {
+		value, err := last.LookupErr(strings.Split(key.field, ".")...)
+		if err != nil {
+			value = bson.RawValue{Type: bson.TypeNull}
+		}
+		if value.Type == bson.TypeArray {
+			return "", core.ValidationError(fmt.Sprintf("sort field %s holds an array and cannot be used for cursor pagination", key.field))
+		}
+		c.Fields = append(c.Fields, key.field)
+		c.Values = append(c.Values, value)
+	}
+
+	data, err := bson.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string, keys []sortKey) (*cursor, error) {
+	invalid := core.ValidationError("after is not a valid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var c cursor
+	if err := bson.Unmarshal(data, &c); err != nil || c.Page < 2 {
+		return nil, invalid
+	}
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, key.field)
+	}
+	if !slices.Equal(c.Fields, fields) || len(c.Values) != len(keys) {
+		return nil, core.ValidationError("after cursor does not match the requested sort")
+	}
+
+	return &c, nil
+}
+
+func queryError(err error) error {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return core.ValidationError(cmdErr.Message)
+	}
+	return err
+}
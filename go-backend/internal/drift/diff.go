@@ -0,0 +1,173 @@
+/*
+AngelaMos | 2026
+diff.go
+*/
+
+package drift
+
+import (
+	"math"
+	"sort"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+type Field struct {
+	Name     string              `json:"name"`
+	Types    []mongodb.TypeCount `json:"types"`
+	Coverage float64             `json:"coverage"`
+}
+
+type FieldChange struct {
+	Field    string   `json:"field"`
+	Types    []string `json:"types"`
+	Coverage float64  `json:"coverage"`
+}
+
+type TypeChange struct {
+	Field   string              `json:"field"`
+	Before  []mongodb.TypeCount `json:"before"`
+	After   []mongodb.TypeCount `json:"after"`
+	Added   []string            `json:"added,omitempty"`
+	Removed []string            `json:"removed,omitempty"`
+}
+
+type CoverageShift struct {
+	Field  string  `json:"field"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Delta  float64 `json:"delta"`
+}
+
+type Diff struct {
+	FromID         string          `json:"from_id"`
+	ToID           string          `json:"to_id"`
+	NewFields      []FieldChange   `json:"new_fields"`
+	VanishedFields []FieldChange   `json:"vanished_fields"`
+	TypeChanges    []TypeChange    `json:"type_changes"`
+	CoverageShifts []CoverageShift `json:"coverage_shifts"`
+	Changes        int             `json:"changes"`
+}
+
+type diffOptions struct {
+	coverageThreshold float64
+	minCoverage       float64
+}
+
+func fieldsFromAnalysis(analysis *mongodb.SchemaAnalysis) []Field {
+	fields := make([]Field, 0, len(analysis.Fields))
+	for _, f := range analysis.Fields {
+		types := f.TypeCounts
+		if types == nil {
+			types = []mongodb.TypeCount{}
+		}
+		fields = append(fields, Field{
+			Name:     f.Name,
+			Types:    types,
+			Coverage: f.Coverage,
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+func diff(from, to []Field, opts diffOptions) *Diff {
+	before := make(map[string]Field, len(from))
+	for _, f := range from {
+		before[f.Name] = f
+	}
+	after := make(map[string]Field, len(to))
+	for _, f := range to {
+		after[f.Name] = f
+	}
+
+	d := &Diff{
+		NewFields:      []FieldChange{},
+		VanishedFields: []FieldChange{},
+		TypeChanges:    []TypeChange{},
+		CoverageShifts: []CoverageShift{},
+	}
+
+	for _, f := range to {
+		prev, ok := before[f.Name]
+		if !ok {
+			if f.Coverage >= opts.minCoverage {
+				d.NewFields = append(d.NewFields, fieldChange(f))
+			}
+			continue
+		}
+
+		if change, ok := typeChange(prev, f, opts); ok {
+			d.TypeChanges = append(d.TypeChanges, change)
+		}
+
+		delta := f.Coverage - prev.Coverage
+		if math.Abs(delta) >= opts.coverageThreshold {
+			d.CoverageShifts = append(d.CoverageShifts, CoverageShift{
+				Field:  f.Name,
+				Before: prev.Coverage,
+				After:  f.Coverage,
+				Delta:  delta,
+			})
+		}
+	}
+
+	for _, f := range from {
+		if _, ok := after[f.Name]; !ok && f.Coverage >= opts.minCoverage {
+			d.VanishedFields = append(d.VanishedFields, fieldChange(f))
+		}
+	}
+
+	d.Changes = len(d.NewFields) + len(d.VanishedFields) + len(d.TypeChanges) + len(d.CoverageShifts)
+	return d
+}
+
+func typeChange(before, after Field, opts diffOptions) (TypeChange, bool) {
+	prev := make(map[string]float64, len(before.Types))
+	for _, tc := range before.Types {
+		prev[tc.Type] = tc.Percentage
+	}
+	next := make(map[string]float64, len(after.Types))
+	for _, tc := range after.Types {
+		next[tc.Type] = tc.Percentage
+	}
+
+	change := TypeChange{
+		Field:  after.Name,
+		Before: before.Types,
+		After:  after.Types,
+	}
+	shifted := false
+
+	for _, tc := range after.Types {
+		p, ok := prev[tc.Type]
+		if !ok {
+			if tc.Percentage >= opts.minCoverage {
+				change.Added = append(change.Added, tc.Type)
+			}
+			continue
+		}
+		if math.Abs(tc.Percentage-p) >= opts.coverageThreshold {
+			shifted = true
+		}
+	}
+	for _, tc := range before.Types {
+		if _, ok := next[tc.Type]; !ok && tc.Percentage >= opts.minCoverage {
+			change.Removed = append(change.Removed, tc.Type)
+		}
+	}
+
+	return change, shifted || len(change.Added) > 0 || len(change.Removed) > 0
+}
+
+func fieldChange(f Field) FieldChange {
+	types := make([]string, 0, len(f.Types))
+	for _, tc := range f.Types {
+		types = append(types, tc.Type)
+	}
+	return FieldChange{
+		Field:    f.Name,
+		Types:    types,
+		Coverage: f.Coverage,
+	}
+}
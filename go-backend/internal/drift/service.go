@@ -0,0 +1,496 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package drift
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	EventType = "schema_drift"
+
+	MaxSampleSize   = config.MaxSchemaDriftSampleSize
+	DefaultLimit    = 50
+	snapshotTimeout = 2 * time.Minute
+	webhookTimeout  = 10 * time.Second
+	maxTickInterval = 5 * time.Minute
+)
+
+type schemaAnalyzer interface {
+	AnalyzeSchema(ctx context.Context, dbName, collName string, sampleSize int) (*mongodb.SchemaAnalysis, error)
+}
+
+type snapshotRepository interface {
+	Create(ctx context.Context, s *sqlite.SchemaSnapshot) error
+	GetByID(ctx context.Context, id string) (*sqlite.SchemaSnapshot, error)
+	Latest(ctx context.Context, clusterName, dbName, collName string) (*sqlite.SchemaSnapshot, error)
+	List(ctx context.Context, f sqlite.SchemaSnapshotFilter) ([]*sqlite.SchemaSnapshot, error)
+	Delete(ctx context.Context, id string) error
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type clusterResolver interface {
+	Get(name string) (*mongodb.Client, error)
+}
+
+type broadcaster interface {
+	Broadcast(msgType string, payload any)
+}
+
+type Service struct {
+	analyzer    schemaAnalyzer
+	repo        snapshotRepository
+	clusters    clusterResolver
+	broadcaster broadcaster
+	cfg         config.SchemaDriftConfig
+	http        *http.Client
+	logger      *slog.Logger
+}
+
+func NewService(analyzer schemaAnalyzer, repo snapshotRepository, clusters clusterResolver, broadcaster broadcaster, cfg config.SchemaDriftConfig, logger *slog.Logger) *Service {
+	return &Service{
+		analyzer:    analyzer,
+		repo:        repo,
+		clusters:    clusters,
+		broadcaster: broadcaster,
+		cfg:         cfg,
+		http:        &http.Client{Timeout: webhookTimeout},
+		logger:      logger,
+	}
+}
+
+type Snapshot struct {
+	ID             string    `json:"id"`
+	Cluster        string    `json:"cluster,omitempty"`
+	Database       string    `json:"database"`
+	Collection     string    `json:"collection"`
+	SampleSize     int64     `json:"sample_size"`
+	TotalDocuments int64     `json:"total_documents"`
+	FieldCount     int       `json:"field_count"`
+	Changes        int       `json:"changes"`
+	PreviousID     string    `json:"previous_id,omitempty"`
+	Fields         []Field   `json:"fields,omitempty"`
+	Drift          *Diff     `json:"drift,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type Event struct {
+	SnapshotID string    `json:"snapshot_id"`
+	Cluster    string    `json:"cluster,omitempty"`
+	Database   string    `json:"database"`
+	Collection string    `json:"collection"`
+	Changes    int       `json:"changes"`
+	Diff       *Diff     `json:"diff"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+type SnapshotInput struct {
+	Cluster    string
+	Database   string
+	Collection string
+	SampleSize int
+}
+
+type ListFilter struct {
+	Cluster    string
+	Database   string
+	Collection string
+	Limit      int
+}
+
+func (s *Service) Start(ctx context.Context) {
+	tick := min(s.cfg.Interval, maxTickInterval)
+
+	go func() {
+		s.SnapshotDue(ctx)
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.SnapshotDue(ctx)
+				s.prune(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Service) SnapshotDue(ctx context.Context) {
+	for _, target := range s.cfg.Collections {
+		if ctx.Err() != nil {
+			return
+		}
+
+		client, err := s.clusters.Get(target.Cluster)
+		if err != nil {
+			s.logger.Warn("skipping schema drift target", "cluster", target.Cluster, "collection", target.Collection, "error", err)
+			continue
+		}
+		clusterName := client.Name()
+		dbName := target.Database
+		if dbName == "" {
+			dbName = client.DefaultDatabase()
+		}
+
+		latest, err := s.repo.Latest(ctx, clusterName, dbName, target.Collection)
+		if err != nil {
+			s.logger.Error("failed to load latest schema snapshot", "collection", target.Collection, "error", err)
+			continue
+		}
+		if latest != nil && time.Since(latest.CreatedAt) < s.cfg.Interval {
+			continue
+		}
+
+		_, err = s.Snapshot(ctx, SnapshotInput{
+			Cluster:    clusterName,
+			Database:   dbName,
+			Collection: target.Collection,
+		})
+		if err != nil {
+			s.logger.Error("schema snapshot failed", "cluster", clusterName, "database", dbName, "collection", target.Collection, "error", err)
+		}
+	}
+}
+
+func (s *Service) Snapshot(ctx context.Context, input SnapshotInput) (*Snapshot, error) {
+	if input.Collection == "" {
+		return nil, core.ValidationError("collection is required")
+	}
+	if input.SampleSize <= 0 {
+		input.SampleSize = s.cfg.SampleSize
+	}
+	if input.SampleSize > MaxSampleSize {
+		return nil, core.ValidationError(fmt.Sprintf("sample_size cannot exceed %d", MaxSampleSize))
+	}
+
+	client, err := s.clusters.Get(input.Cluster)
+	if err != nil {
+		return nil, core.ValidationError("unknown cluster " + input.Cluster)
+	}
+	input.Cluster = client.Name()
+	if input.Database == "" {
+		input.Database = client.DefaultDatabase()
+	}
+
+	previous, err := s.repo.Latest(ctx, input.Cluster, input.Database, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzeCtx, cancel := context.WithTimeout(mongodb.WithClient(ctx, client), snapshotTimeout)
+	defer cancel()
+
+	analysis, err := s.analyzer.AnalyzeSchema(analyzeCtx, input.Database, input.Collection, input.SampleSize)
+	if err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) {
+			return nil, core.ValidationError(cmdErr.Message)
+		}
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		ID:             uuid.New().String(),
+		Cluster:        input.Cluster,
+		Database:       input.Database,
+		Collection:     input.Collection,
+		SampleSize:     analysis.SampleSize,
+		TotalDocuments: analysis.TotalDocuments,
+		Fields:         fieldsFromAnalysis(analysis),
+		CreatedAt:      time.Now(),
+	}
+	snapshot.FieldCount = len(snapshot.Fields)
+
+	if previous != nil {
+		prev, err := toSnapshot(previous)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.PreviousID = prev.ID
+		snapshot.Drift = s.diff(prev, snapshot)
+		snapshot.Changes = snapshot.Drift.Changes
+	}
+
+	if err := s.store(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	if snapshot.Changes > 0 {
+		s.notify(snapshot)
+	}
+
+	return snapshot, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*Snapshot, error) {
+	row, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, core.NotFoundError("schema snapshot")
+	}
+	return toSnapshot(row)
+}
+
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]Snapshot, error) {
+	rows, err := s.repo.List(ctx, sqlite.SchemaSnapshotFilter{
+		ClusterName:    filter.Cluster,
+		DatabaseName:   filter.Database,
+		CollectionName: filter.Collection,
+		Limit:          listLimit(filter.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshot, err := toSnapshot(row)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Fields = nil
+		snapshot.Drift = nil
+		snapshots = append(snapshots, *snapshot)
+	}
+	return snapshots, nil
+}
+
+func (s *Service) Events(ctx context.Context, filter ListFilter) ([]Event, error) {
+	rows, err := s.repo.List(ctx, sqlite.SchemaSnapshotFilter{
+		ClusterName:    filter.Cluster,
+		DatabaseName:   filter.Database,
+		CollectionName: filter.Collection,
+		DriftOnly:      true,
+		Limit:          listLimit(filter.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(rows))
+	for _, row := range rows {
+		snapshot, err := toSnapshot(row)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, toEvent(snapshot))
+	}
+	return events, nil
+}
+
+func (s *Service) Diff(ctx context.Context, fromID, toID string) (*Diff, error) {
+	if toID == "" {
+		return nil, core.ValidationError("to is required")
+	}
+	to, err := s.Get(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromID == "" {
+		if to.PreviousID == "" {
+			return nil, core.ValidationError("snapshot has no previous snapshot to compare against")
+		}
+		fromID = to.PreviousID
+	}
+	from, err := s.Get(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Cluster != to.Cluster || from.Database != to.Database || from.Collection != to.Collection {
+		return nil, core.ValidationError("snapshots must belong to the same collection")
+	}
+
+	return s.diff(from, to), nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	row, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return core.NotFoundError("schema snapshot")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *Service) diff(from, to *Snapshot) *Diff {
+	d := diff(from.Fields, to.Fields, diffOptions{
+		coverageThreshold: s.cfg.CoverageThreshold,
+		minCoverage:       s.cfg.MinCoverage,
+	})
+	d.FromID = from.ID
+	d.ToID = to.ID
+	return d
+}
+
+func (s *Service) store(ctx context.Context, snapshot *Snapshot) error {
+	fields, err := json.Marshal(snapshot.Fields)
+	if err != nil {
+		return fmt.Errorf("encode snapshot fields: %w", err)
+	}
+
+	row := &sqlite.SchemaSnapshot{
+		ID:             snapshot.ID,
+		ClusterName:    snapshot.Cluster,
+		DatabaseName:   snapshot.Database,
+		CollectionName: snapshot.Collection,
+		SampleSize:     snapshot.SampleSize,
+		TotalDocuments: snapshot.TotalDocuments,
+		FieldCount:     snapshot.FieldCount,
+		Fields:         string(fields),
+		ChangeCount:    snapshot.Changes,
+		CreatedAt:      snapshot.CreatedAt,
+	}
+	if snapshot.PreviousID != "" {
+		row.PreviousID = sql.NullString{String: snapshot.PreviousID, Valid: true}
+	}
+	if snapshot.Drift != nil {
+		drift, err := json.Marshal(snapshot.Drift)
+		if err != nil {
+			return fmt.Errorf("encode snapshot drift: %w", err)
+		}
+		row.Drift = sql.NullString{String: string(drift), Valid: true}
+	}
+
+	return s.repo.Create(ctx, row)
+}
+
+func (s *Service) notify(snapshot *Snapshot) {
+	event := toEvent(snapshot)
+
+	s.logger.Warn("schema drift detected",
+		"cluster", event.Cluster,
+		"database", event.Database,
+		"collection", event.Collection,
+		"changes", event.Changes,
+		"new_fields", len(event.Diff.NewFields),
+		"vanished_fields", len(event.Diff.VanishedFields),
+		"type_changes", len(event.Diff.TypeChanges),
+		"coverage_shifts", len(event.Diff.CoverageShifts),
+	)
+
+	if s.broadcaster != nil {
+		s.broadcaster.Broadcast(EventType, event)
+	}
+
+	if s.cfg.WebhookURL != "" {
+		go s.sendWebhook(event)
+	}
+}
+
+func (s *Service) sendWebhook(event Event) {
+	body, err := json.Marshal(map[string]any{
+		"type":  EventType,
+		"event": event,
+	})
+	if err != nil {
+		s.logger.Error("failed to encode schema drift webhook", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		s.logger.Error("failed to build schema drift webhook", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		s.logger.Error("schema drift webhook failed", "error", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		s.logger.Error("schema drift webhook rejected", "status", resp.StatusCode)
+	}
+}
+
+func (s *Service) prune(ctx context.Context) {
+	if s.cfg.RetentionDays <= 0 {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.cfg.RetentionDays)
+	if _, err := s.repo.DeleteBefore(ctx, cutoff); err != nil {
+		s.logger.Error("failed to prune schema snapshots", "error", err)
+	}
+}
+
+func toSnapshot(row *sqlite.SchemaSnapshot) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		ID:             row.ID,
+		Cluster:        row.ClusterName,
+		Database:       row.DatabaseName,
+		Collection:     row.CollectionName,
+		SampleSize:     row.SampleSize,
+		TotalDocuments: row.TotalDocuments,
+		FieldCount:     row.FieldCount,
+		Changes:        row.ChangeCount,
+		PreviousID:     row.PreviousID.String,
+		CreatedAt:      row.CreatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.Fields), &snapshot.Fields); err != nil {
+		return nil, fmt.Errorf("decode snapshot fields: %w", err)
+	}
+	if row.Drift.Valid && row.Drift.String != "" {
+		snapshot.Drift = &Diff{}
+		if err := json.Unmarshal([]byte(row.Drift.String), snapshot.Drift); err != nil {
+			return nil, fmt.Errorf("decode snapshot drift: %w", err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func toEvent(snapshot *Snapshot) Event {
+	return Event{
+		SnapshotID: snapshot.ID,
+		Cluster:    snapshot.Cluster,
+		Database:   snapshot.Database,
+		Collection: snapshot.Collection,
+		Changes:    snapshot.Changes,
+		Diff:       snapshot.Drift,
+		DetectedAt: snapshot.CreatedAt,
+	}
+}
+
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
@@ -0,0 +1,278 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+var explainableCommands = map[string]bool{
+	"find":          true,
+	"aggregate":     true,
+	"count":         true,
+	"distinct":      true,
+	"findAndModify": true,
+	"findandmodify": true,
+	"update":        true,
+	"delete":        true,
+}
+
+var strippedCommandFields = map[string]bool{
+	"lsid":                 true,
+	"txnNumber":            true,
+	"autocommit":           true,
+	"startTransaction":     true,
+	"readConcern":          true,
+	"writeConcern":         true,
+	"apiVersion":           true,
+	"apiStrict":            true,
+	"apiDeprecationErrors": true,
+	"fromMongos":           true,
+	"needsMerge":           true,
+	"collectionUUID":       true,
+	"shardVersion":         true,
+	"databaseVersion":      true,
+}
+
+type explainRepository interface {
+	Explain(ctx context.Context, dbName string, cmd bson.D, verbosity string) (*mongodb.ExplainResult, error)
+	GetProfileEntry(ctx context.Context, dbName, namespace string, ts time.Time) (bson.Raw, error)
+}
+
+type slowQueryStore interface {
+	GetByTimestamp(ctx context.Context, clusterName, namespace string, ts time.Time) (*sqlite.StoredSlowQuery, error)
+}
+
+type Service struct {
+	repo  explainRepository
+	store slowQueryStore
+}
+
+func NewService(repo explainRepository, store slowQueryStore) *Service {
+	return &Service{repo: repo, store: store}
+}
+
+type QueryInput struct {
+	Filter     json.RawMessage
+	Sort       json.RawMessage
+	Projection json.RawMessage
+	Hint       json.RawMessage
+	Pipeline   json.RawMessage
+	Limit      int64
+	Skip       int64
+	Verbosity  string
+}
+
+func (s *Service) ExplainQuery(ctx context.Context, dbName, collName string, input QueryInput) (*mongodb.ExplainResult, error) {
+	verbosity, err := resolveVerbosity(input.Verbosity)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := buildQueryCommand(collName, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.run(ctx, dbName, cmd, verbosity)
+}
+
+func (s *Service) ExplainProfiled(ctx context.Context, namespace string, ts time.Time, verbosity string) (*mongodb.ExplainResult, error) {
+	verbosity, err := resolveVerbosity(verbosity)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName, collName, ok := strings.Cut(namespace, ".")
+	if !ok || dbName == "" || collName == "" {
+		return nil, core.ValidationError("namespace must be in the form database.collection")
+	}
+
+	cmd, err := s.profiledCommand(ctx, dbName, collName, namespace, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.run(ctx, dbName, cmd, verbosity)
+}
+
+func (s *Service) profiledCommand(ctx context.Context, dbName, collName, namespace string, ts time.Time) (bson.D, error) {
+	stored, err := s.store.GetByTimestamp(ctx, mongodb.ClusterName(ctx), namespace, ts)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil && stored.Command != "" {
+		statement, err := mongodb.ParseExtJSONDocument([]byte(stored.Command))
+		if err != nil {
+			return nil, fmt.Errorf("decode stored command: %w", err)
+		}
+		raw, err := bson.Marshal(statement)
+		if err != nil {
+			return nil, fmt.Errorf("encode stored command: %w", err)
+		}
+		return explainableCommand(stored.Op, raw, collName)
+	}
+
+	entry, err := s.repo.GetProfileEntry(ctx, dbName, namespace, ts)
+	if errors.Is(err, mongodb.ErrProfileEntryNotFound) {
+		return nil, core.NotFoundError("profile entry")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return profiledCommand(entry, collName)
+}
+
+func (s *Service) run(ctx context.Context, dbName string, cmd bson.D, verbosity string) (*mongodb.ExplainResult, error) {
+	result, err := s.repo.Explain(ctx, dbName, cmd, verbosity)
+	if err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) {
+			return nil, core.ValidationError(cmdErr.Message)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func resolveVerbosity(verbosity string) (string, error) {
+	switch verbosity {
+	case "":
+		return mongodb.VerbosityExecutionStats, nil
+	case mongodb.VerbosityQueryPlanner, mongodb.VerbosityExecutionStats, mongodb.VerbosityAllPlansExecution:
+		return verbosity, nil
+	}
+	return "", core.ValidationError("verbosity must be queryPlanner, executionStats or allPlansExecution")
+}
+
+func buildQueryCommand(collName string, input QueryInput) (bson.D, error) {
+	if len(input.Pipeline) > 0 {
+		pipeline, err := mongodb.ParseExtJSONPipeline(input.Pipeline)
+		if err != nil {
+			return nil, core.ValidationError("invalid pipeline: " + err.Error())
+		}
+		return bson.D{
+			{Key: "aggregate", Value: collName},
+			{Key: "pipeline", Value: pipeline},
+			{Key: "cursor", Value: bson.D{}},
+		}, nil
+	}
+
+	filter, err := mongodb.ParseExtJSONDocument(input.Filter)
+	if err != nil {
+		return nil, core.ValidationError("invalid filter: " + err.Error())
+	}
+
+	cmd := bson.D{
+		{Key: "find", Value: collName},
+		{Key: "filter", Value: filter},
+	}
+
+	if len(input.Sort) > 0 {
+		sortDoc, err := mongodb.ParseExtJSONDocument(input.Sort)
+		if err != nil {
+			return nil, core.ValidationError("invalid sort: " + err.Error())
+		}
+		cmd = append(cmd, bson.E{Key: "sort", Value: sortDoc})
+	}
+
+	if len(input.Projection) > 0 {
+		projection, err := mongodb.ParseExtJSONDocument(input.Projection)
+		if err != nil {
+			return nil, core.ValidationError("invalid projection: " + err.Error())
+		}
+		cmd = append(cmd, bson.E{Key: "projection", Value: projection})
+	}
+
+	if len(input.Hint) > 0 {
+		var indexName string
+		if err := json.Unmarshal(input.Hint, &indexName); err == nil {
+			cmd = append(cmd, bson.E{Key: "hint", Value: indexName})
+		} else {
+			hint, err := mongodb.ParseExtJSONDocument(input.Hint)
+			if err != nil {
+				return nil, core.ValidationError("invalid hint: " + err.Error())
+			}
+			cmd = append(cmd, bson.E{Key: "hint", Value: hint})
+		}
+	}
+
+	if input.Skip > 0 {
+		cmd = append(cmd, bson.E{Key: "skip", Value: input.Skip})
+	}
+	if input.Limit > 0 {
+		cmd = append(cmd, bson.E{Key: "limit", Value: input.Limit})
+	}
+
+	return cmd, nil
+}
+
+func profiledCommand(entry bson.Raw, collName string) (bson.D, error) {
+	op, _ := entry.Lookup("op").StringValueOK()
+
+	field := "command"
+	if op == "getmore" {
+		field = "originatingCommand"
+	}
+
+	raw, err := entry.LookupErr(field)
+	if err != nil || raw.Type != bson.TypeEmbeddedDocument {
+		return nil, core.ValidationError("profile entry has no command to explain")
+	}
+
+	return explainableCommand(op, raw.Document(), collName)
+}
+
+func explainableCommand(op string, command bson.Raw, collName string) (bson.D, error) {
+	var statement bson.D
+	if err := bson.Unmarshal(command, &statement); err != nil {
+		return nil, fmt.Errorf("decode profiled command: %w", err)
+	}
+
+	switch op {
+	case "update":
+		if _, err := command.LookupErr("q"); err == nil {
+			return bson.D{
+				{Key: "update", Value: collName},
+				{Key: "updates", Value: bson.A{statement}},
+			}, nil
+		}
+	case "remove":
+		if _, err := command.LookupErr("q"); err == nil {
+			return bson.D{
+				{Key: "delete", Value: collName},
+				{Key: "deletes", Value: bson.A{statement}},
+			}, nil
+		}
+	}
+
+	cmd := make(bson.D, 0, len(statement))
+	for _, e := range statement {
+		if strings.HasPrefix(e.Key, "$") || strippedCommandFields[e.Key] {
+			continue
+		}
+		cmd = append(cmd, e)
+	}
+
+	if len(cmd) == 0 || !explainableCommands[cmd[0].Key] {
+		return nil, core.ValidationError(fmt.Sprintf("operation %q cannot be explained", op))
+	}
+
+	return cmd, nil
+}
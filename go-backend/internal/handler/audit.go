@@ -0,0 +1,94 @@
+/*
+AngelaMos | 2026
+audit.go
+*/
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+type auditRepository interface {
+	ListRecent(ctx context.Context, action string, limit int) ([]*sqlite.AuditEntry, error)
+}
+
+type AuditHandler struct {
+	repo auditRepository
+}
+
+func NewAuditHandler(repo auditRepository) *AuditHandler {
+	return &AuditHandler{repo: repo}
+}
+
+func (h *AuditHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/api/audit", h.List)
+}
+
+type AuditEntryResponse struct {
+	ID           string          `json:"id"`
+	Action       string          `json:"action"`
+	Resource     string          `json:"resource"`
+	ResourceID   string          `json:"resource_id"`
+	ClusterName  string          `json:"cluster_name,omitempty"`
+	DatabaseName string          `json:"database_name,omitempty"`
+	Details      json.RawMessage `json:"details,omitempty"`
+	RequestID    string          `json:"request_id,omitempty"`
+	RemoteAddr   string          `json:"remote_addr,omitempty"`
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func toAuditEntryResponse(e *sqlite.AuditEntry) *AuditEntryResponse {
+	resp := &AuditEntryResponse{
+		ID:           e.ID,
+		Action:       e.Action,
+		Resource:     e.Resource,
+		ResourceID:   e.ResourceID,
+		ClusterName:  e.ClusterName,
+		DatabaseName: e.DatabaseName,
+		RequestID:    e.RequestID,
+		RemoteAddr:   e.RemoteAddr,
+		Status:       e.Status,
+		CreatedAt:    e.CreatedAt,
+	}
+	if e.Details.Valid {
+		resp.Details = json.RawMessage(e.Details.String)
+	}
+	if e.ErrorMessage.Valid {
+		resp.ErrorMessage = e.ErrorMessage.String
+	}
+	return resp
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	entries, err := h.repo.ListRecent(r.Context(), r.URL.Query().Get("action"), limit)
+	if err != nil {
+		core.InternalServerError(w, err)
+		return
+	}
+
+	response := make([]*AuditEntryResponse, len(entries))
+	for i, e := range entries {
+		response[i] = toAuditEntryResponse(e)
+	}
+
+	core.OK(w, response)
+}
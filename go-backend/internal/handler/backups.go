@@ -50,6 +50,7 @@ func (h *BackupsHandler) RegisterRoutes(r chi.Router) {
 
 type BackupResponse struct {
 	ID           string     `json:"id"`
+	ClusterName  string     `json:"cluster_name"`
 	DatabaseName string     `json:"database_name"`
 	FilePath     string     `json:"file_path"`
 	SizeBytes    int64      `json:"size_bytes"`
@@ -64,6 +65,7 @@ type BackupResponse struct {
 func toBackupResponse(b *sqlite.Backup) *BackupResponse {
 	resp := &BackupResponse{
 		ID:           b.ID,
+		ClusterName:  b.ClusterName,
 		DatabaseName: b.DatabaseName,
 		FilePath:     b.FilePath,
 		SizeBytes:    b.SizeBytes,
@@ -110,11 +112,11 @@ type CreateBackupRequest struct {
 func (h *BackupsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req CreateBackupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		req.DatabaseName = h.database
+		req = CreateBackupRequest{}
 	}
 
 	if req.DatabaseName == "" {
-		req.DatabaseName = h.database
+		req.DatabaseName = defaultDatabase(r, h.database)
 	}
 
 	backup, err := h.service.TriggerBackup(r.Context(), req.DatabaseName, "manual")
@@ -0,0 +1,106 @@
+/*
+AngelaMos | 2026
+clusters.go
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/cluster"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+type clusterService interface {
+	List() []mongodb.ClusterStatus
+	Get(name string) (*mongodb.ClusterStatus, error)
+	CheckHealth(ctx context.Context, name string) (*mongodb.ClusterHealth, error)
+	Create(ctx context.Context, input cluster.CreateInput) (*mongodb.ClusterStatus, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type ClustersHandler struct {
+	service clusterService
+}
+
+func NewClustersHandler(service clusterService) *ClustersHandler {
+	return &ClustersHandler{service: service}
+}
+
+func (h *ClustersHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/clusters", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{cluster}", h.Get)
+		r.Delete("/{cluster}", h.Delete)
+		r.Get("/{cluster}/health", h.Health)
+	})
+}
+
+func (h *ClustersHandler) List(w http.ResponseWriter, r *http.Request) {
+	core.OK(w, h.service.List())
+}
+
+func (h *ClustersHandler) Get(w http.ResponseWriter, r *http.Request) {
+	status, err := h.service.Get(chi.URLParam(r, "cluster"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, status)
+}
+
+type CreateClusterRequest struct {
+	Name        string `json:"name"`
+	URI         string `json:"uri"`
+	Database    string `json:"database"`
+	MaxPoolSize uint64 `json:"max_pool_size"`
+	MinPoolSize uint64 `json:"min_pool_size"`
+}
+
+func (h *ClustersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateClusterRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	status, err := h.service.Create(r.Context(), cluster.CreateInput{
+		Name:        req.Name,
+		URI:         req.URI,
+		Database:    req.Database,
+		MaxPoolSize: req.MaxPoolSize,
+		MinPoolSize: req.MinPoolSize,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Created(w, status)
+}
+
+func (h *ClustersHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "cluster")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.NoContent(w)
+}
+
+func (h *ClustersHandler) Health(w http.ResponseWriter, r *http.Request) {
+	health, err := h.service.CheckHealth(r.Context(), chi.URLParam(r, "cluster"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, health)
+}
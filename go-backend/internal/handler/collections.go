@@ -7,14 +7,25 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
-	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/documents"
+	"github.com/carterperez-dev/templates/go-backend/internal/explain"
+	"github.com/carterperez-dev/templates/go-backend/internal/indexes"
+	"github.com/carterperez-dev/templates/go-backend/internal/middleware"
 	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/validator"
 )
 
 type collectionsRepository interface {
@@ -22,20 +33,79 @@ type collectionsRepository interface {
 	GetCollectionStats(ctx context.Context, dbName, collName string) (*mongodb.CollectionStats, error)
 	AnalyzeSchema(ctx context.Context, dbName, collName string, sampleSize int) (*mongodb.SchemaAnalysis, error)
 	GetIndexes(ctx context.Context, dbName, collName string) ([]mongodb.IndexInfo, error)
-	SampleDocuments(ctx context.Context, dbName, collName string, limit int) ([]bson.M, error)
 	GetFieldStats(ctx context.Context, dbName, collName, fieldName string) (*mongodb.FieldStats, error)
 	CountByFieldValue(ctx context.Context, dbName, collName, fieldName string, value any) (int64, error)
 }
 
+type indexManager interface {
+	GetIndex(ctx context.Context, dbName, collName, indexName string) (*indexes.IndexDetail, error)
+	CreateIndex(ctx context.Context, dbName, collName string, input indexes.CreateInput, actor indexes.Actor) (*indexes.Build, error)
+	GetBuild(ctx context.Context, id string) (*indexes.Build, error)
+	DropIndex(ctx context.Context, dbName, collName, indexName, token string, actor indexes.Actor) error
+	SetHidden(ctx context.Context, dbName, collName, indexName string, hidden bool, token string, actor indexes.Actor) (*mongodb.IndexInfo, error)
+}
+
+type documentBrowser interface {
+	Browse(ctx context.Context, dbName, collName string, input documents.BrowseInput) (*documents.Page, error)
+}
+
+type documentEditor interface {
+	Get(ctx context.Context, dbName, collName, id, format string) (*documents.Document, error)
+	Replace(ctx context.Context, dbName, collName, id string, replacement json.RawMessage, hash, format string, actor documents.Actor) (*documents.Document, error)
+	Update(ctx context.Context, dbName, collName, id string, update json.RawMessage, hash, format string, actor documents.Actor) (*documents.Document, error)
+	Delete(ctx context.Context, dbName, collName, id, hash string, actor documents.Actor) error
+}
+
+type documentExporter interface {
+	Prepare(dbName, collName string, input documents.ExportInput) (*documents.Export, error)
+	StartJob(ctx context.Context, dbName, collName string, input documents.ExportInput) (*documents.Job, error)
+}
+
+type documentImporter interface {
+	Import(ctx context.Context, dbName, collName string, body io.Reader, input documents.ImportInput, actor documents.Actor) (*documents.ImportResult, error)
+}
+
+type documentAggregator interface {
+	Run(ctx context.Context, dbName, collName string, input documents.AggregateInput, actor documents.Actor) (*documents.AggregateResult, error)
+	Preview(ctx context.Context, dbName, collName string, input documents.AggregateInput) (*documents.AggregatePreview, error)
+}
+
+type validatorService interface {
+	Get(ctx context.Context, dbName, collName, format string) (*validator.Validator, error)
+	Generate(ctx context.Context, dbName, collName string, input validator.GenerateInput) (*validator.Generated, error)
+	Apply(ctx context.Context, dbName, collName string, input validator.ApplyInput, actor validator.Actor) (*validator.Validator, error)
+	Report(ctx context.Context, dbName, collName string, input validator.ReportInput) (*validator.Report, error)
+}
+
+type queryExplainer interface {
+	ExplainQuery(ctx context.Context, dbName, collName string, input explain.QueryInput) (*mongodb.ExplainResult, error)
+}
+
 type CollectionsHandler struct {
-	repo     collectionsRepository
-	database string
+	repo       collectionsRepository
+	explainer  queryExplainer
+	indexes    indexManager
+	documents  documentBrowser
+	editor     documentEditor
+	exporter   documentExporter
+	importer   documentImporter
+	aggregator documentAggregator
+	validators validatorService
+	database   string
 }
 
-func NewCollectionsHandler(repo collectionsRepository, database string) *CollectionsHandler {
+func NewCollectionsHandler(repo collectionsRepository, explainer queryExplainer, indexes indexManager, documents documentBrowser, editor documentEditor, exporter documentExporter, importer documentImporter, aggregator documentAggregator, validators validatorService, database string) *CollectionsHandler {
 	return &CollectionsHandler{
-		repo:     repo,
-		database: database,
+		repo:       repo,
+		explainer:  explainer,
+		indexes:    indexes,
+		documents:  documents,
+		editor:     editor,
+		exporter:   exporter,
+		importer:   importer,
+		aggregator: aggregator,
+		validators: validators,
+		database:   database,
 	}
 }
 
@@ -45,9 +115,28 @@ func (h *CollectionsHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/{name}", h.GetStats)
 		r.Get("/{name}/schema", h.GetSchema)
 		r.Get("/{name}/indexes", h.GetIndexes)
-		r.Get("/{name}/documents", h.SampleDocuments)
+		r.Post("/{name}/indexes", h.CreateIndex)
+		r.Get("/{name}/indexes/{index}", h.GetIndex)
+		r.Delete("/{name}/indexes/{index}", h.DropIndex)
+		r.Patch("/{name}/indexes/{index}", h.UpdateIndex)
+		r.Get("/{name}/index-builds/{id}", h.GetIndexBuild)
+		r.Get("/{name}/documents", h.BrowseDocuments)
+		r.Post("/{name}/documents", h.QueryDocuments)
+		r.Get("/{name}/documents/{id}", h.GetDocument)
+		r.Put("/{name}/documents/{id}", h.ReplaceDocument)
+		r.Patch("/{name}/documents/{id}", h.UpdateDocument)
+		r.Delete("/{name}/documents/{id}", h.DeleteDocument)
+		r.Get("/{name}/export", h.Export)
+		r.Post("/{name}/export", h.StartExport)
+		r.Post("/{name}/import", h.Import)
+		r.Post("/{name}/aggregate", h.Aggregate)
+		r.Get("/{name}/validator", h.GetValidator)
+		r.Put("/{name}/validator", h.ApplyValidator)
+		r.Post("/{name}/validator/generate", h.GenerateValidator)
+		r.Post("/{name}/validator/report", h.ValidatorReport)
 		r.Get("/{name}/fields/{field}", h.GetFieldStats)
 		r.Get("/{name}/count", h.CountByField)
+		r.Post("/{name}/explain", h.Explain)
 	})
 }
 
@@ -58,10 +147,7 @@ type CollectionsListResponse struct {
 }
 
 func (h *CollectionsHandler) List(w http.ResponseWriter, r *http.Request) {
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
-	}
+	dbName := databaseParam(r, h.database)
 
 	collections, err := h.repo.ListCollections(r.Context(), dbName)
 	if err != nil {
@@ -80,10 +166,7 @@ func (h *CollectionsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 func (h *CollectionsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
-	}
+	dbName := databaseParam(r, h.database)
 
 	stats, err := h.repo.GetCollectionStats(r.Context(), dbName, name)
 	if err != nil {
@@ -96,10 +179,7 @@ func (h *CollectionsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 
 func (h *CollectionsHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
-	}
+	dbName := databaseParam(r, h.database)
 
 	sampleSize := 1000
 	if s := r.URL.Query().Get("sample_size"); s != "" {
@@ -108,21 +188,24 @@ func (h *CollectionsHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
 	schema, err := h.repo.AnalyzeSchema(r.Context(), dbName, name, sampleSize)
 	if err != nil {
 		core.InternalServerError(w, err)
 		return
 	}
+	schema.Encode(format)
 
 	core.OK(w, schema)
 }
 
 func (h *CollectionsHandler) GetIndexes(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
-	}
+	dbName := databaseParam(r, h.database)
 
 	indexes, err := h.repo.GetIndexes(r.Context(), dbName, name)
 	if err != nil {
@@ -133,47 +216,610 @@ func (h *CollectionsHandler) GetIndexes(w http.ResponseWriter, r *http.Request)
 	core.OK(w, indexes)
 }
 
-type DocumentsResponse struct {
-	Collection string   `json:"collection"`
-	Count      int      `json:"count"`
-	Documents  []bson.M `json:"documents"`
+type CreateIndexRequest struct {
+	Keys                    json.RawMessage `json:"keys"`
+	Name                    string          `json:"name"`
+	Unique                  bool            `json:"unique"`
+	Sparse                  bool            `json:"sparse"`
+	Hidden                  bool            `json:"hidden"`
+	ExpireAfterSeconds      *int64          `json:"expire_after_seconds"`
+	PartialFilterExpression json.RawMessage `json:"partial_filter_expression"`
+	Collation               json.RawMessage `json:"collation"`
+	Weights                 json.RawMessage `json:"weights"`
+	DefaultLanguage         string          `json:"default_language"`
+	LanguageOverride        string          `json:"language_override"`
+	WildcardProjection      json.RawMessage `json:"wildcard_projection"`
 }
 
-func (h *CollectionsHandler) SampleDocuments(w http.ResponseWriter, r *http.Request) {
-	name := chi.URLParam(r, "name")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
+func (h *CollectionsHandler) CreateIndex(w http.ResponseWriter, r *http.Request) {
+	var req CreateIndexRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	build, err := h.indexes.CreateIndex(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), indexes.CreateInput{
+		Keys:                    req.Keys,
+		Name:                    req.Name,
+		Unique:                  req.Unique,
+		Sparse:                  req.Sparse,
+		Hidden:                  req.Hidden,
+		ExpireAfterSeconds:      req.ExpireAfterSeconds,
+		PartialFilterExpression: req.PartialFilterExpression,
+		Collation:               req.Collation,
+		Weights:                 req.Weights,
+		DefaultLanguage:         req.DefaultLanguage,
+		LanguageOverride:        req.LanguageOverride,
+		WildcardProjection:      req.WildcardProjection,
+	}, indexActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.JSON(w, http.StatusAccepted, build)
+}
+
+func (h *CollectionsHandler) GetIndex(w http.ResponseWriter, r *http.Request) {
+	detail, err := h.indexes.GetIndex(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "index"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, detail)
+}
+
+func (h *CollectionsHandler) GetIndexBuild(w http.ResponseWriter, r *http.Request) {
+	build, err := h.indexes.GetBuild(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, build)
+}
+
+func (h *CollectionsHandler) DropIndex(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("confirm_token")
+	if token == "" {
+		core.BadRequest(w, "confirm_token query parameter is required")
+		return
+	}
+
+	err := h.indexes.DropIndex(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "index"), token, indexActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, map[string]string{"message": "index dropped"})
+}
+
+type UpdateIndexRequest struct {
+	Hidden *bool `json:"hidden"`
+}
+
+func (h *CollectionsHandler) UpdateIndex(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("confirm_token")
+	if token == "" {
+		core.BadRequest(w, "confirm_token query parameter is required")
+		return
+	}
+
+	var req UpdateIndexRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+	if req.Hidden == nil {
+		core.BadRequest(w, "hidden is required")
+		return
+	}
+
+	info, err := h.indexes.SetHidden(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "index"), *req.Hidden, token, indexActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, info)
+}
+
+func indexActor(r *http.Request) indexes.Actor {
+	return indexes.Actor{
+		RequestID:  middleware.GetRequestID(r.Context()),
+		RemoteAddr: r.RemoteAddr,
+	}
+}
+
+func (h *CollectionsHandler) BrowseDocuments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := documents.BrowseInput{
+		After: query.Get("after"),
+	}
+	for key, dst := range map[string]*json.RawMessage{
+		"filter":     &input.Filter,
+		"sort":       &input.Sort,
+		"projection": &input.Projection,
+	} {
+		if v := query.Get(key); v != "" {
+			*dst = json.RawMessage(v)
+		}
 	}
 
-	limit := 20
-	if l := r.URL.Query().Get("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
-			limit = parsed
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			core.BadRequest(w, "limit must be an integer")
+			return
 		}
+		input.Limit = parsed
+	}
+
+	h.browse(w, r, input)
+}
+
+type QueryDocumentsRequest struct {
+	Filter     json.RawMessage `json:"filter"`
+	Sort       json.RawMessage `json:"sort"`
+	Projection json.RawMessage `json:"projection"`
+	Limit      int             `json:"limit"`
+	After      string          `json:"after"`
+}
+
+func (h *CollectionsHandler) QueryDocuments(w http.ResponseWriter, r *http.Request) {
+	var req QueryDocumentsRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
 	}
 
-	docs, err := h.repo.SampleDocuments(r.Context(), dbName, name, limit)
+	h.browse(w, r, documents.BrowseInput{
+		Filter:     req.Filter,
+		Sort:       req.Sort,
+		Projection: req.Projection,
+		Limit:      req.Limit,
+		After:      req.After,
+	})
+}
+
+func (h *CollectionsHandler) browse(w http.ResponseWriter, r *http.Request, input documents.BrowseInput) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+	input.Format = format
+
+	page, err := h.documents.Browse(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), input)
 	if err != nil {
-		core.InternalServerError(w, err)
+		respondError(w, err)
 		return
 	}
 
-	response := DocumentsResponse{
-		Collection: name,
-		Count:      len(docs),
-		Documents:  docs,
+	core.Paginated(w, page, page.Page, page.PageSize, page.Total)
+}
+
+func (h *CollectionsHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
 	}
 
-	core.OK(w, response)
+	doc, err := h.editor.Get(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "id"), format)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, doc)
+}
+
+type ReplaceDocumentRequest struct {
+	Document json.RawMessage `json:"document"`
+	Hash     string          `json:"hash"`
+}
+
+func (h *CollectionsHandler) ReplaceDocument(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReplaceDocumentRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	doc, err := h.editor.Replace(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "id"), req.Document, req.Hash, format, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, doc)
+}
+
+type UpdateDocumentRequest struct {
+	Update json.RawMessage `json:"update"`
+	Hash   string          `json:"hash"`
+}
+
+func (h *CollectionsHandler) UpdateDocument(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateDocumentRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	doc, err := h.editor.Update(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "id"), req.Update, req.Hash, format, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, doc)
+}
+
+func (h *CollectionsHandler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
+	hash := r.URL.Query().Get("hash")
+	if hash == "" {
+		core.BadRequest(w, "hash query parameter is required")
+		return
+	}
+
+	err := h.editor.Delete(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), chi.URLParam(r, "id"), hash, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, map[string]string{"message": "document deleted"})
+}
+
+func documentActor(r *http.Request) documents.Actor {
+	return documents.Actor{
+		RequestID:  middleware.GetRequestID(r.Context()),
+		RemoteAddr: r.RemoteAddr,
+	}
+}
+
+func (h *CollectionsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := documents.ExportInput{
+		Format:  query.Get("format"),
+		ExtJSON: query.Get("extjson"),
+	}
+	for key, dst := range map[string]*json.RawMessage{
+		"filter":     &input.Filter,
+		"sort":       &input.Sort,
+		"projection": &input.Projection,
+	} {
+		if v := query.Get(key); v != "" {
+			*dst = json.RawMessage(v)
+		}
+	}
+	if fields := query.Get("fields"); fields != "" {
+		input.Fields = strings.Split(fields, ",")
+	}
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.ParseInt(l, 10, 64)
+		if err != nil {
+			core.BadRequest(w, "limit must be an integer")
+			return
+		}
+		input.Limit = parsed
+	}
+
+	export, err := h.exporter.Prepare(databaseParam(r, h.database), chi.URLParam(r, "name"), input)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	out := &attachmentWriter{w: w, contentType: export.ContentType, filename: export.Filename}
+	if _, err := export.Stream(r.Context(), out); err != nil {
+		if !out.started {
+			respondError(w, err)
+		}
+		return
+	}
+	out.start()
+}
+
+func (h *CollectionsHandler) StartExport(w http.ResponseWriter, r *http.Request) {
+	var input documents.ExportInput
+	if err := core.DecodeJSON(r, &input); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	job, err := h.exporter.StartJob(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), input)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.JSON(w, http.StatusAccepted, job)
+}
+
+func (h *CollectionsHandler) Import(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	input := documents.ImportInput{
+		Format: query.Get("format"),
+		Mode:   query.Get("mode"),
+		Infer:  query.Get("infer") != "false",
+		DryRun: query.Get("dry_run") == "true",
+	}
+	if key := query.Get("key"); key != "" {
+		input.Key = strings.Split(key, ",")
+	}
+	if b := query.Get("batch_size"); b != "" {
+		parsed, err := strconv.Atoi(b)
+		if err != nil {
+			core.BadRequest(w, "batch_size must be an integer")
+			return
+		}
+		input.BatchSize = parsed
+	}
+	if schema := query.Get("schema"); schema != "" {
+		if err := json.Unmarshal([]byte(schema), &input.Schema); err != nil {
+			core.BadRequest(w, "schema must be a JSON object of field to type")
+			return
+		}
+	}
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, err := uploadedFile(r)
+		if err != nil {
+			core.BadRequest(w, err.Error())
+			return
+		}
+		defer part.Close()
+		body = part
+
+		if input.Format == "" {
+			input.Format = importFormatFromFilename(part.FileName())
+		}
+	}
+	if input.Format == "" {
+		input.Format = documents.FormatJSONL
+	}
+
+	result, err := h.importer.Import(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), body, input, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, result)
+}
+
+func uploadedFile(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.New("invalid multipart body")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, errors.New("multipart body must include a file part")
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+func importFormatFromFilename(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return documents.FormatCSV
+	case ".json":
+		return documents.FormatJSON
+	}
+	return ""
+}
+
+type AggregateRequest struct {
+	Pipeline     json.RawMessage `json:"pipeline"`
+	MaxTimeMS    int64           `json:"max_time_ms"`
+	Limit        int             `json:"limit"`
+	AllowWrites  bool            `json:"allow_writes"`
+	AllowDiskUse bool            `json:"allow_disk_use"`
+	Preview      bool            `json:"preview"`
+	PreviewLimit int             `json:"preview_limit"`
+}
+
+func (h *CollectionsHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req AggregateRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	input := documents.AggregateInput{
+		Pipeline:     req.Pipeline,
+		MaxTimeMS:    req.MaxTimeMS,
+		Limit:        req.Limit,
+		AllowWrites:  req.AllowWrites,
+		AllowDiskUse: req.AllowDiskUse,
+		PreviewLimit: req.PreviewLimit,
+		Format:       format,
+	}
+	dbName := databaseParam(r, h.database)
+	collName := chi.URLParam(r, "name")
+
+	if req.Preview {
+		preview, err := h.aggregator.Preview(r.Context(), dbName, collName, input)
+		if err != nil {
+			respondError(w, err)
+			return
+		}
+		core.OK(w, preview)
+		return
+	}
+
+	result, err := h.aggregator.Run(r.Context(), dbName, collName, input, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, result)
+}
+
+func (h *CollectionsHandler) GetValidator(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	v, err := h.validators.Get(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), format)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, v)
+}
+
+type GenerateValidatorRequest struct {
+	SampleSize        int     `json:"sample_size"`
+	RequiredThreshold float64 `json:"required_threshold"`
+	EnumMaxValues     *int    `json:"enum_max_values"`
+}
+
+func (h *CollectionsHandler) GenerateValidator(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req GenerateValidatorRequest
+	if r.ContentLength != 0 {
+		if err := core.DecodeJSON(r, &req); err != nil {
+			core.BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	enumMaxValues := validator.DefaultEnumMaxValues
+	if req.EnumMaxValues != nil {
+		enumMaxValues = *req.EnumMaxValues
+	}
+
+	generated, err := h.validators.Generate(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), validator.GenerateInput{
+		SampleSize:        req.SampleSize,
+		RequiredThreshold: req.RequiredThreshold,
+		EnumMaxValues:     enumMaxValues,
+		Format:            format,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, generated)
+}
+
+type ApplyValidatorRequest struct {
+	Validator        json.RawMessage `json:"validator"`
+	ValidationLevel  string          `json:"validation_level"`
+	ValidationAction string          `json:"validation_action"`
+}
+
+func (h *CollectionsHandler) ApplyValidator(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req ApplyValidatorRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	v, err := h.validators.Apply(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), validator.ApplyInput{
+		Validator:        req.Validator,
+		ValidationLevel:  req.ValidationLevel,
+		ValidationAction: req.ValidationAction,
+		Format:           format,
+		ConfirmToken:     r.URL.Query().Get("confirm_token"),
+	}, validatorActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, v)
+}
+
+func validatorActor(r *http.Request) validator.Actor {
+	return validator.Actor{
+		RequestID:  middleware.GetRequestID(r.Context()),
+		RemoteAddr: r.RemoteAddr,
+	}
+}
+
+type ValidatorReportRequest struct {
+	Validator json.RawMessage `json:"validator"`
+	Samples   int             `json:"samples"`
+}
+
+func (h *CollectionsHandler) ValidatorReport(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req ValidatorReportRequest
+	if r.ContentLength != 0 {
+		if err := core.DecodeJSON(r, &req); err != nil {
+			core.BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	report, err := h.validators.Report(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), validator.ReportInput{
+		Validator: req.Validator,
+		Samples:   req.Samples,
+		Format:    format,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, report)
 }
 
 func (h *CollectionsHandler) GetFieldStats(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
 	field := chi.URLParam(r, "field")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
+	dbName := databaseParam(r, h.database)
+
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
 	}
 
 	stats, err := h.repo.GetFieldStats(r.Context(), dbName, name, field)
@@ -181,6 +827,7 @@ func (h *CollectionsHandler) GetFieldStats(w http.ResponseWriter, r *http.Reques
 		core.InternalServerError(w, err)
 		return
 	}
+	stats.Encode(format)
 
 	core.OK(w, stats)
 }
@@ -194,10 +841,7 @@ type CountResponse struct {
 
 func (h *CollectionsHandler) CountByField(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
-	dbName := r.URL.Query().Get("database")
-	if dbName == "" {
-		dbName = h.database
-	}
+	dbName := databaseParam(r, h.database)
 
 	field := r.URL.Query().Get("field")
 	if field == "" {
@@ -240,3 +884,39 @@ func (h *CollectionsHandler) CountByField(w http.ResponseWriter, r *http.Request
 
 	core.OK(w, response)
 }
+
+type ExplainRequest struct {
+	Filter     json.RawMessage `json:"filter"`
+	Sort       json.RawMessage `json:"sort"`
+	Projection json.RawMessage `json:"projection"`
+	Hint       json.RawMessage `json:"hint"`
+	Pipeline   json.RawMessage `json:"pipeline"`
+	Limit      int64           `json:"limit"`
+	Skip       int64           `json:"skip"`
+	Verbosity  string          `json:"verbosity"`
+}
+
+func (h *CollectionsHandler) Explain(w http.ResponseWriter, r *http.Request) {
+	var req ExplainRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	result, err := h.explainer.ExplainQuery(r.Context(), databaseParam(r, h.database), chi.URLParam(r, "name"), explain.QueryInput{
+		Filter:     req.Filter,
+		Sort:       req.Sort,
+		Projection: req.Projection,
+		Hint:       req.Hint,
+		Pipeline:   req.Pipeline,
+		Limit:      req.Limit,
+		Skip:       req.Skip,
+		Verbosity:  req.Verbosity,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, result)
+}
@@ -0,0 +1,97 @@
+/*
+AngelaMos | 2026
+exports.go
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/documents"
+)
+
+type exportJobs interface {
+	GetJob(ctx context.Context, id string) (*documents.Job, error)
+	ListJobs(ctx context.Context, limit int) ([]documents.Job, error)
+	OpenJob(ctx context.Context, id string) (*documents.Job, *os.File, error)
+	DeleteJob(ctx context.Context, id string) error
+}
+
+type ExportsHandler struct {
+	jobs exportJobs
+}
+
+func NewExportsHandler(jobs exportJobs) *ExportsHandler {
+	return &ExportsHandler{jobs: jobs}
+}
+
+func (h *ExportsHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/exports", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Get("/{id}", h.Get)
+		r.Get("/{id}/download", h.Download)
+		r.Delete("/{id}", h.Delete)
+	})
+}
+
+func (h *ExportsHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	jobs, err := h.jobs.ListJobs(r.Context(), limit)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, jobs)
+}
+
+func (h *ExportsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.GetJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, job)
+}
+
+func (h *ExportsHandler) Download(w http.ResponseWriter, r *http.Request) {
+	job, file, err := h.jobs.OpenJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		core.InternalServerError(w, err)
+		return
+	}
+
+	filename := job.Collection + "." + job.Format
+	w.Header().Set("Content-Disposition", attachmentDisposition(filename))
+	http.ServeContent(w, r, filename, info.ModTime(), file)
+}
+
+func (h *ExportsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.jobs.DeleteJob(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, map[string]string{"message": "export deleted"})
+}
@@ -0,0 +1,68 @@
+/*
+AngelaMos | 2026
+indexes.go
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/indexes"
+)
+
+type indexService interface {
+	UsageReport(ctx context.Context, dbName string, unusedSince time.Time) (*indexes.UsageReport, error)
+}
+
+type IndexesHandler struct {
+	service  indexService
+	database string
+}
+
+func NewIndexesHandler(service indexService, database string) *IndexesHandler {
+	return &IndexesHandler{service: service, database: database}
+}
+
+func (h *IndexesHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/indexes", func(r chi.Router) {
+		r.Get("/report", h.UsageReport)
+	})
+}
+
+func (h *IndexesHandler) UsageReport(w http.ResponseWriter, r *http.Request) {
+	var unusedSince time.Time
+
+	if v := r.URL.Query().Get("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			parsed, err = time.Parse(time.DateOnly, v)
+		}
+		if err != nil {
+			core.BadRequest(w, "since must be a date such as 2026-01-31 or an RFC3339 timestamp")
+			return
+		}
+		unusedSince = parsed
+	} else if v := r.URL.Query().Get("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			core.BadRequest(w, "days must be a positive integer")
+			return
+		}
+		unusedSince = time.Now().AddDate(0, 0, -days)
+	}
+
+	report, err := h.service.UsageReport(r.Context(), databaseParam(r, h.database), unusedSince)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, report)
+}
@@ -0,0 +1,127 @@
+/*
+AngelaMos | 2026
+kpis.go
+*/
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/kpi"
+)
+
+type kpiService interface {
+	List(ctx context.Context) ([]kpi.Definition, error)
+	Create(ctx context.Context, input kpi.CreateInput) (*kpi.Definition, error)
+	Delete(ctx context.Context, name string) error
+	History(ctx context.Context, name string, window time.Duration, limit int) ([]kpi.Sample, error)
+}
+
+type KPIsHandler struct {
+	service kpiService
+}
+
+func NewKPIsHandler(service kpiService) *KPIsHandler {
+	return &KPIsHandler{service: service}
+}
+
+func (h *KPIsHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/kpis", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Delete("/{name}", h.Delete)
+		r.Get("/{name}/history", h.History)
+	})
+}
+
+func (h *KPIsHandler) List(w http.ResponseWriter, r *http.Request) {
+	definitions, err := h.service.List(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, definitions)
+}
+
+type CreateKPIRequest struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Cluster    string          `json:"cluster"`
+	Database   string          `json:"database"`
+	Collection string          `json:"collection"`
+	Type       string          `json:"type"`
+	Filter     json.RawMessage `json:"filter"`
+	Pipeline   json.RawMessage `json:"pipeline"`
+	Exclusions []kpi.Exclusion `json:"exclusions"`
+}
+
+func (h *KPIsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateKPIRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	def, err := h.service.Create(r.Context(), kpi.CreateInput{
+		Name:       req.Name,
+		Label:      req.Label,
+		Cluster:    req.Cluster,
+		Database:   req.Database,
+		Collection: req.Collection,
+		Type:       req.Type,
+		Filter:     req.Filter,
+		Pipeline:   req.Pipeline,
+		Exclusions: req.Exclusions,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Created(w, def)
+}
+
+func (h *KPIsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.NoContent(w)
+}
+
+func (h *KPIsHandler) History(w http.ResponseWriter, r *http.Request) {
+	window := 24 * time.Hour
+	if v := r.URL.Query().Get("window"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			core.BadRequest(w, "window must be a positive duration such as 24h")
+			return
+		}
+		window = parsed
+	}
+
+	limit := 1000
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 10000 {
+			limit = parsed
+		}
+	}
+
+	samples, err := h.service.History(r.Context(), chi.URLParam(r, "name"), window, limit)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, samples)
+}
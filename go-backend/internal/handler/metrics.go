@@ -9,41 +9,58 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/carterperez-dev/templates/go-backend/internal/core"
 	"github.com/carterperez-dev/templates/go-backend/internal/metrics"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
 )
 
 type metricsService interface {
-	GetDashboardMetrics(ctx context.Context) (*metrics.DashboardMetrics, error)
-	GetSlowQueries(ctx context.Context, minMillis, limit int) (*metrics.SlowQueryReport, error)
-	GetProfilingStatus(ctx context.Context) (*metrics.ProfilingStatus, error)
-	SetProfilingLevel(ctx context.Context, level, slowMs int) error
-	AnalyzeSlowQueries(ctx context.Context, minMillis, limit int) (*metrics.SlowQueryAnalysis, error)
+	GetDashboardMetrics(ctx context.Context, dbName string) (*metrics.DashboardMetrics, error)
+	GetDatabasesOverview(ctx context.Context) (*metrics.DatabasesOverview, error)
+	GetSlowQueries(ctx context.Context, dbName string, minMillis, limit int) (*metrics.SlowQueryReport, error)
+	GetProfilingStatus(ctx context.Context, dbName string) (*metrics.ProfilingStatus, error)
+	SetProfilingLevel(ctx context.Context, dbName string, level, slowMs int) error
+	AnalyzeSlowQueries(ctx context.Context, dbName string, minMillis, limit int) (*metrics.SlowQueryAnalysis, error)
+	GetQueryShapes(ctx context.Context, dbName string, minMillis, sample int, sortBy string, limit int) (*metrics.QueryShapeReport, error)
+	GetTop(ctx context.Context, sortBy string, limit int) (*metrics.TopReport, error)
+}
+
+type profiledExplainer interface {
+	ExplainProfiled(ctx context.Context, namespace string, ts time.Time, verbosity string) (*mongodb.ExplainResult, error)
 }
 
 type MetricsHandler struct {
-	service metricsService
+	service   metricsService
+	explainer profiledExplainer
 }
 
-func NewMetricsHandler(service metricsService) *MetricsHandler {
-	return &MetricsHandler{service: service}
+func NewMetricsHandler(service metricsService, explainer profiledExplainer) *MetricsHandler {
+	return &MetricsHandler{
+		service:   service,
+		explainer: explainer,
+	}
 }
 
 func (h *MetricsHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/api/metrics", func(r chi.Router) {
 		r.Get("/", h.GetMetrics)
+		r.Get("/databases", h.GetDatabasesOverview)
 		r.Get("/slow-queries", h.GetSlowQueries)
 		r.Get("/slow-queries/analyze", h.AnalyzeSlowQueries)
+		r.Get("/slow-queries/shapes", h.GetQueryShapes)
+		r.Post("/slow-queries/explain", h.ExplainSlowQuery)
 		r.Get("/profiling", h.GetProfilingStatus)
 		r.Put("/profiling", h.SetProfilingLevel)
+		r.Get("/top", h.GetTop)
 	})
 }
 
 func (h *MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
-	m, err := h.service.GetDashboardMetrics(r.Context())
+	m, err := h.service.GetDashboardMetrics(r.Context(), r.URL.Query().Get("database"))
 	if err != nil {
 		core.InternalServerError(w, err)
 		return
@@ -52,6 +69,16 @@ func (h *MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
 	core.OK(w, m)
 }
 
+func (h *MetricsHandler) GetDatabasesOverview(w http.ResponseWriter, r *http.Request) {
+	overview, err := h.service.GetDatabasesOverview(r.Context())
+	if err != nil {
+		core.InternalServerError(w, err)
+		return
+	}
+
+	core.OK(w, overview)
+}
+
 func (h *MetricsHandler) GetSlowQueries(w http.ResponseWriter, r *http.Request) {
 	minMillis := 100
 	if v := r.URL.Query().Get("min_millis"); v != "" {
@@ -67,7 +94,7 @@ func (h *MetricsHandler) GetSlowQueries(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	report, err := h.service.GetSlowQueries(r.Context(), minMillis, limit)
+	report, err := h.service.GetSlowQueries(r.Context(), r.URL.Query().Get("database"), minMillis, limit)
 	if err != nil {
 		core.InternalServerError(w, err)
 		return
@@ -91,7 +118,7 @@ func (h *MetricsHandler) AnalyzeSlowQueries(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	analysis, err := h.service.AnalyzeSlowQueries(r.Context(), minMillis, limit)
+	analysis, err := h.service.AnalyzeSlowQueries(r.Context(), r.URL.Query().Get("database"), minMillis, limit)
 	if err != nil {
 		core.InternalServerError(w, err)
 		return
@@ -100,8 +127,66 @@ func (h *MetricsHandler) AnalyzeSlowQueries(w http.ResponseWriter, r *http.Reque
 	core.OK(w, analysis)
 }
 
+func (h *MetricsHandler) GetQueryShapes(w http.ResponseWriter, r *http.Request) {
+	minMillis := 100
+	if v := r.URL.Query().Get("min_millis"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			minMillis = parsed
+		}
+	}
+
+	sample := 1000
+	if v := r.URL.Query().Get("sample"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 10000 {
+			sample = parsed
+		}
+	}
+
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	report, err := h.service.GetQueryShapes(r.Context(), r.URL.Query().Get("database"), minMillis, sample, r.URL.Query().Get("sort"), limit)
+	if err != nil {
+		core.InternalServerError(w, err)
+		return
+	}
+
+	core.OK(w, report)
+}
+
+type ExplainSlowQueryRequest struct {
+	Namespace string    `json:"namespace"`
+	Timestamp time.Time `json:"timestamp"`
+	Verbosity string    `json:"verbosity"`
+}
+
+func (h *MetricsHandler) ExplainSlowQuery(w http.ResponseWriter, r *http.Request) {
+	var req ExplainSlowQueryRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	if req.Namespace == "" || req.Timestamp.IsZero() {
+		core.BadRequest(w, "namespace and timestamp are required")
+		return
+	}
+
+	result, err := h.explainer.ExplainProfiled(r.Context(), req.Namespace, req.Timestamp, req.Verbosity)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, result)
+}
+
 func (h *MetricsHandler) GetProfilingStatus(w http.ResponseWriter, r *http.Request) {
-	status, err := h.service.GetProfilingStatus(r.Context())
+	status, err := h.service.GetProfilingStatus(r.Context(), r.URL.Query().Get("database"))
 	if err != nil {
 		core.InternalServerError(w, err)
 		return
@@ -111,8 +196,9 @@ func (h *MetricsHandler) GetProfilingStatus(w http.ResponseWriter, r *http.Reque
 }
 
 type SetProfilingRequest struct {
-	Level  int `json:"level"`
-	SlowMs int `json:"slow_ms"`
+	Database string `json:"database"`
+	Level    int    `json:"level"`
+	SlowMs   int    `json:"slow_ms"`
 }
 
 func (h *MetricsHandler) SetProfilingLevel(w http.ResponseWriter, r *http.Request) {
@@ -127,10 +213,27 @@ func (h *MetricsHandler) SetProfilingLevel(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.service.SetProfilingLevel(r.Context(), req.Level, req.SlowMs); err != nil {
+	if err := h.service.SetProfilingLevel(r.Context(), req.Database, req.Level, req.SlowMs); err != nil {
 		core.InternalServerError(w, err)
 		return
 	}
 
 	core.OK(w, map[string]string{"status": "profiling level updated"})
 }
+
+func (h *MetricsHandler) GetTop(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	report, err := h.service.GetTop(r.Context(), r.URL.Query().Get("sort"), limit)
+	if err != nil {
+		core.InternalServerError(w, err)
+		return
+	}
+
+	core.OK(w, report)
+}
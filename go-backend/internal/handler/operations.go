@@ -0,0 +1,119 @@
+/*
+AngelaMos | 2026
+operations.go
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/middleware"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/operations"
+)
+
+type operationsService interface {
+	ListOperations(ctx context.Context, filter mongodb.OperationFilter) ([]operations.Operation, error)
+	GetOperation(ctx context.Context, opID int64) (*operations.OperationDetail, error)
+	KillOperation(ctx context.Context, opID int64, token string, actor operations.Actor) error
+}
+
+type OperationsHandler struct {
+	service operationsService
+}
+
+func NewOperationsHandler(service operationsService) *OperationsHandler {
+	return &OperationsHandler{service: service}
+}
+
+func (h *OperationsHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/operations", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Get("/{opid}", h.Get)
+		r.Delete("/{opid}", h.Kill)
+	})
+}
+
+type OperationsListResponse struct {
+	Count      int                    `json:"count"`
+	Operations []operations.Operation `json:"operations"`
+}
+
+func (h *OperationsHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := mongodb.OperationFilter{
+		Namespace:       q.Get("namespace"),
+		OpType:          q.Get("op"),
+		Client:          q.Get("client"),
+		IncludeInactive: q.Get("include_inactive") == "true",
+	}
+
+	if v := q.Get("min_running_ms"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			core.BadRequest(w, "min_running_ms must be a non-negative integer")
+			return
+		}
+		filter.MinRunningMs = parsed
+	}
+
+	ops, err := h.service.ListOperations(r.Context(), filter)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, OperationsListResponse{
+		Count:      len(ops),
+		Operations: ops,
+	})
+}
+
+func (h *OperationsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	opID, err := strconv.ParseInt(chi.URLParam(r, "opid"), 10, 64)
+	if err != nil {
+		core.BadRequest(w, "opid must be an integer")
+		return
+	}
+
+	op, err := h.service.GetOperation(r.Context(), opID)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, op)
+}
+
+func (h *OperationsHandler) Kill(w http.ResponseWriter, r *http.Request) {
+	opID, err := strconv.ParseInt(chi.URLParam(r, "opid"), 10, 64)
+	if err != nil {
+		core.BadRequest(w, "opid must be an integer")
+		return
+	}
+
+	token := r.URL.Query().Get("confirm_token")
+	if token == "" {
+		core.BadRequest(w, "confirm_token query parameter is required")
+		return
+	}
+
+	actor := operations.Actor{
+		RequestID:  middleware.GetRequestID(r.Context()),
+		RemoteAddr: r.RemoteAddr,
+	}
+
+	if err := h.service.KillOperation(r.Context(), opID, token, actor); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, map[string]string{"message": "operation killed"})
+}
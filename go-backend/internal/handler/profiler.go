@@ -0,0 +1,202 @@
+/*
+AngelaMos | 2026
+profiler.go
+*/
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/profiler"
+)
+
+type profilerService interface {
+	Search(ctx context.Context, filter profiler.SearchFilter) ([]profiler.SlowQuery, int, error)
+}
+
+type profilerSessions interface {
+	Start(ctx context.Context, input profiler.SessionInput) (*profiler.Session, error)
+	Stop(ctx context.Context, id string) (*profiler.Session, error)
+	Get(ctx context.Context, id string) (*profiler.Session, error)
+	List(ctx context.Context, limit int) ([]profiler.Session, error)
+	Report(ctx context.Context, id string) (*profiler.SessionReport, error)
+}
+
+type ProfilerHandler struct {
+	service  profilerService
+	sessions profilerSessions
+	database string
+}
+
+func NewProfilerHandler(service profilerService, sessions profilerSessions, database string) *ProfilerHandler {
+	return &ProfilerHandler{service: service, sessions: sessions, database: database}
+}
+
+func (h *ProfilerHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/profiler", func(r chi.Router) {
+		r.Get("/slow-queries", h.SearchSlowQueries)
+		r.Get("/sessions", h.ListSessions)
+		r.Post("/sessions", h.StartSession)
+		r.Get("/sessions/{id}", h.GetSession)
+		r.Post("/sessions/{id}/stop", h.StopSession)
+		r.Get("/sessions/{id}/report", h.GetSessionReport)
+	})
+}
+
+func (h *ProfilerHandler) SearchSlowQueries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := profiler.SearchFilter{
+		Database:    query.Get("database"),
+		Namespace:   query.Get("namespace"),
+		Op:          query.Get("op"),
+		PlanSummary: query.Get("plan"),
+		SortBy:      query.Get("sort"),
+		Page:        1,
+		PageSize:    50,
+	}
+
+	if client, ok := mongodb.ClientFromContext(r.Context()); ok {
+		filter.Cluster = client.Name()
+	}
+
+	for key, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		v := query.Get(key)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			core.BadRequest(w, key+" must be an RFC3339 timestamp")
+			return
+		}
+		*dst = parsed
+	}
+
+	if v := query.Get("min_millis"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			core.BadRequest(w, "min_millis must be a non-negative integer")
+			return
+		}
+		filter.MinMillis = parsed
+	}
+
+	if p := query.Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			filter.Page = parsed
+		}
+	}
+
+	if ps := query.Get("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 500 {
+			filter.PageSize = parsed
+		}
+	}
+
+	queries, total, err := h.service.Search(r.Context(), filter)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Paginated(w, queries, filter.Page, filter.PageSize, total)
+}
+
+type StartSessionRequest struct {
+	Database   string          `json:"database"`
+	Level      int             `json:"level"`
+	SlowMs     int             `json:"slow_ms"`
+	SampleRate float64         `json:"sample_rate"`
+	Filter     json.RawMessage `json:"filter"`
+	Duration   string          `json:"duration"`
+}
+
+func (h *ProfilerHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	var req StartSessionRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		core.BadRequest(w, "duration must be a duration such as 15m")
+		return
+	}
+
+	if req.Database == "" {
+		req.Database = defaultDatabase(r, h.database)
+	}
+
+	session, err := h.sessions.Start(r.Context(), profiler.SessionInput{
+		Database:   req.Database,
+		Level:      req.Level,
+		SlowMs:     req.SlowMs,
+		SampleRate: req.SampleRate,
+		Filter:     req.Filter,
+		Duration:   duration,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Created(w, session)
+}
+
+func (h *ProfilerHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	sessions, err := h.sessions.List(r.Context(), limit)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, sessions)
+}
+
+func (h *ProfilerHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.sessions.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, session)
+}
+
+func (h *ProfilerHandler) StopSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.sessions.Stop(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, session)
+}
+
+func (h *ProfilerHandler) GetSessionReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.sessions.Report(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, report)
+}
@@ -0,0 +1,74 @@
+/*
+AngelaMos | 2026
+respond.go
+*/
+
+package handler
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+func respondError(w http.ResponseWriter, err error) {
+	if core.IsAppError(err) {
+		core.JSONError(w, err)
+		return
+	}
+	core.InternalServerError(w, err)
+}
+
+func defaultDatabase(r *http.Request, fallback string) string {
+	if cluster, ok := mongodb.ClientFromContext(r.Context()); ok && cluster.DefaultDatabase() != "" {
+		return cluster.DefaultDatabase()
+	}
+	return fallback
+}
+
+func databaseParam(r *http.Request, fallback string) string {
+	if dbName := r.URL.Query().Get("database"); dbName != "" {
+		return dbName
+	}
+	return defaultDatabase(r, fallback)
+}
+
+func extJSONFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return mongodb.ExtJSONRelaxed, true
+	}
+	if !mongodb.ValidExtJSONFormat(format) {
+		core.BadRequest(w, "format must be relaxed or canonical")
+		return "", false
+	}
+	return format, true
+}
+
+type attachmentWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (a *attachmentWriter) start() {
+	if a.started {
+		return
+	}
+	a.started = true
+	a.w.Header().Set("Content-Type", a.contentType)
+	a.w.Header().Set("Content-Disposition", attachmentDisposition(a.filename))
+	a.w.WriteHeader(http.StatusOK)
+}
+
+func (a *attachmentWriter) Write(p []byte) (int, error) {
+	a.start()
+	return a.w.Write(p)
+}
+
+func attachmentDisposition(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
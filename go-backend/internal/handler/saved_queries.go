@@ -0,0 +1,209 @@
+/*
+AngelaMos | 2026
+saved_queries.go
+*/
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/documents"
+	"github.com/carterperez-dev/templates/go-backend/internal/savedquery"
+)
+
+type savedQueryService interface {
+	List(ctx context.Context, filter savedquery.ListFilter) ([]savedquery.SavedQuery, error)
+	Tags(ctx context.Context) ([]savedquery.Tag, error)
+	Get(ctx context.Context, id string) (*savedquery.SavedQuery, error)
+	Create(ctx context.Context, input savedquery.Input) (*savedquery.SavedQuery, error)
+	Update(ctx context.Context, id string, input savedquery.Input) (*savedquery.SavedQuery, error)
+	Delete(ctx context.Context, id string) error
+	Run(ctx context.Context, id string, input savedquery.RunInput, actor documents.Actor) (*savedquery.RunResult, error)
+}
+
+type SavedQueriesHandler struct {
+	service  savedQueryService
+	database string
+}
+
+func NewSavedQueriesHandler(service savedQueryService, database string) *SavedQueriesHandler {
+	return &SavedQueriesHandler{
+		service:  service,
+		database: database,
+	}
+}
+
+func (h *SavedQueriesHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/saved-queries", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/tags", h.Tags)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+		r.Post("/{id}/run", h.Run)
+	})
+}
+
+func (h *SavedQueriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	queries, err := h.service.List(r.Context(), savedquery.ListFilter{
+		Cluster:    query.Get("cluster"),
+		Database:   query.Get("database"),
+		Collection: query.Get("collection"),
+		Tag:        query.Get("tag"),
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, queries)
+}
+
+func (h *SavedQueriesHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.Tags(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, tags)
+}
+
+func (h *SavedQueriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	q, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, q)
+}
+
+type SavedQueryRequest struct {
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	Cluster         string                 `json:"cluster"`
+	Database        string                 `json:"database"`
+	Collection      string                 `json:"collection"`
+	Kind            string                 `json:"kind"`
+	Filter          json.RawMessage        `json:"filter"`
+	Sort            json.RawMessage        `json:"sort"`
+	Projection      json.RawMessage        `json:"projection"`
+	Pipeline        json.RawMessage        `json:"pipeline"`
+	Parameters      []savedquery.Parameter `json:"parameters"`
+	Tags            []string               `json:"tags"`
+	CacheTTLSeconds int64                  `json:"cache_ttl_seconds"`
+}
+
+func (h *SavedQueriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decodeInput(w, r)
+	if !ok {
+		return
+	}
+
+	q, err := h.service.Create(r.Context(), input)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Created(w, q)
+}
+
+func (h *SavedQueriesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decodeInput(w, r)
+	if !ok {
+		return
+	}
+
+	q, err := h.service.Update(r.Context(), chi.URLParam(r, "id"), input)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, q)
+}
+
+func (h *SavedQueriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.NoContent(w)
+}
+
+type RunSavedQueryRequest struct {
+	Params    map[string]json.RawMessage `json:"params"`
+	Limit     int                        `json:"limit"`
+	After     string                     `json:"after"`
+	MaxTimeMS int64                      `json:"max_time_ms"`
+	Refresh   bool                       `json:"refresh"`
+}
+
+func (h *SavedQueriesHandler) Run(w http.ResponseWriter, r *http.Request) {
+	format, ok := extJSONFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var req RunSavedQueryRequest
+	if r.ContentLength != 0 {
+		if err := core.DecodeJSON(r, &req); err != nil {
+			core.BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	result, err := h.service.Run(r.Context(), chi.URLParam(r, "id"), savedquery.RunInput{
+		Params:    req.Params,
+		Limit:     req.Limit,
+		After:     req.After,
+		MaxTimeMS: req.MaxTimeMS,
+		Format:    format,
+		Refresh:   req.Refresh,
+	}, documentActor(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, result)
+}
+
+func (h *SavedQueriesHandler) decodeInput(w http.ResponseWriter, r *http.Request) (savedquery.Input, bool) {
+	var req SavedQueryRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return savedquery.Input{}, false
+	}
+	if req.Database == "" {
+		req.Database = defaultDatabase(r, h.database)
+	}
+
+	return savedquery.Input{
+		Name:            req.Name,
+		Description:     req.Description,
+		Cluster:         req.Cluster,
+		Database:        req.Database,
+		Collection:      req.Collection,
+		Kind:            req.Kind,
+		Filter:          req.Filter,
+		Sort:            req.Sort,
+		Projection:      req.Projection,
+		Pipeline:        req.Pipeline,
+		Parameters:      req.Parameters,
+		Tags:            req.Tags,
+		CacheTTLSeconds: req.CacheTTLSeconds,
+	}, true
+}
@@ -0,0 +1,140 @@
+/*
+AngelaMos | 2026
+schema_drift.go
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/drift"
+)
+
+type schemaDriftService interface {
+	List(ctx context.Context, filter drift.ListFilter) ([]drift.Snapshot, error)
+	Get(ctx context.Context, id string) (*drift.Snapshot, error)
+	Snapshot(ctx context.Context, input drift.SnapshotInput) (*drift.Snapshot, error)
+	Delete(ctx context.Context, id string) error
+	Diff(ctx context.Context, fromID, toID string) (*drift.Diff, error)
+	Events(ctx context.Context, filter drift.ListFilter) ([]drift.Event, error)
+}
+
+type SchemaDriftHandler struct {
+	service schemaDriftService
+}
+
+func NewSchemaDriftHandler(service schemaDriftService) *SchemaDriftHandler {
+	return &SchemaDriftHandler{service: service}
+}
+
+func (h *SchemaDriftHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/api/schema-drift", func(r chi.Router) {
+		r.Get("/snapshots", h.List)
+		r.Post("/snapshots", h.Snapshot)
+		r.Get("/snapshots/{id}", h.Get)
+		r.Delete("/snapshots/{id}", h.Delete)
+		r.Get("/diff", h.Diff)
+		r.Get("/events", h.Events)
+	})
+}
+
+func (h *SchemaDriftHandler) List(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := h.service.List(r.Context(), driftFilter(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, snapshots)
+}
+
+type SnapshotRequest struct {
+	Cluster    string `json:"cluster"`
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	SampleSize int    `json:"sample_size"`
+}
+
+func (h *SchemaDriftHandler) Snapshot(w http.ResponseWriter, r *http.Request) {
+	var req SnapshotRequest
+	if err := core.DecodeJSON(r, &req); err != nil {
+		core.BadRequest(w, "invalid request body")
+		return
+	}
+
+	snapshot, err := h.service.Snapshot(r.Context(), drift.SnapshotInput{
+		Cluster:    req.Cluster,
+		Database:   req.Database,
+		Collection: req.Collection,
+		SampleSize: req.SampleSize,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.Created(w, snapshot)
+}
+
+func (h *SchemaDriftHandler) Get(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, snapshot)
+}
+
+func (h *SchemaDriftHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.NoContent(w)
+}
+
+func (h *SchemaDriftHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	diff, err := h.service.Diff(r.Context(), query.Get("from"), query.Get("to"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, diff)
+}
+
+func (h *SchemaDriftHandler) Events(w http.ResponseWriter, r *http.Request) {
+	events, err := h.service.Events(r.Context(), driftFilter(r))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	core.OK(w, events)
+}
+
+func driftFilter(r *http.Request) drift.ListFilter {
+	query := r.URL.Query()
+
+	filter := drift.ListFilter{
+		Cluster:    query.Get("cluster"),
+		Database:   query.Get("database"),
+		Collection: query.Get("collection"),
+	}
+	if l := query.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			filter.Limit = parsed
+		}
+	}
+	return filter
+}
@@ -0,0 +1,569 @@
+/*
+AngelaMos | 2026
+manage.go
+*/
+
+package indexes
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	BuildRunning   = "running"
+	BuildCompleted = "completed"
+	BuildFailed    = "failed"
+
+	confirmTokenTTL = 2 * time.Minute
+	buildRetention  = time.Hour
+)
+
+var indexKeyTypes = map[string]bool{
+	"text":        true,
+	"2d":          true,
+	"2dsphere":    true,
+	"hashed":      true,
+	"geoHaystack": true,
+}
+
+type indexWriter interface {
+	GetIndex(ctx context.Context, dbName, collName, indexName string) (*mongodb.IndexInfo, error)
+	CreateIndex(ctx context.Context, dbName, collName string, spec bson.D) error
+	DropIndex(ctx context.Context, dbName, collName, indexName string) error
+	SetIndexHidden(ctx context.Context, dbName, collName, indexName string, hidden bool) error
+	GetIndexBuilds(ctx context.Context, namespace string) ([]mongodb.IndexBuildOp, error)
+}
+
+type auditRepository interface {
+	Create(ctx context.Context, e *sqlite.AuditEntry) error
+}
+
+type Manager struct {
+	repo   indexWriter
+	audit  auditRepository
+	tokens map[string]confirmToken
+	builds map[string]*Build
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+type confirmToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+type Actor struct {
+	RequestID  string
+	RemoteAddr string
+}
+
+type CreateInput struct {
+	Keys                    json.RawMessage
+	Name                    string
+	Unique                  bool
+	Sparse                  bool
+	Hidden                  bool
+	ExpireAfterSeconds      *int64
+	PartialFilterExpression json.RawMessage
+	Collation               json.RawMessage
+	Weights                 json.RawMessage
+	DefaultLanguage         string
+	LanguageOverride        string
+	WildcardProjection      json.RawMessage
+}
+
+type Build struct {
+	ID         string                 `json:"id"`
+	Cluster    string                 `json:"cluster"`
+	Database   string                 `json:"database"`
+	Collection string                 `json:"collection"`
+	Index      string                 `json:"index"`
+	Spec       json.RawMessage        `json:"spec"`
+	Status     string                 `json:"status"`
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Progress   []mongodb.IndexBuildOp `json:"progress,omitempty"`
+}
+
+type IndexDetail struct {
+	mongodb.IndexInfo
+	ConfirmToken     string    `json:"confirm_token"`
+	ConfirmExpiresAt time.Time `json:"confirm_expires_at"`
+}
+
+func NewManager(repo indexWriter, audit auditRepository, logger *slog.Logger) *Manager {
+	return &Manager{
+		repo:   repo,
+		audit:  audit,
+		tokens: make(map[string]confirmToken),
+		builds: make(map[string]*Build),
+		logger: logger,
+	}
+}
+
+func (m *Manager) GetIndex(ctx context.Context, dbName, collName, indexName string) (*IndexDetail, error) {
+	info, err := m.lookup(ctx, dbName, collName, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := m.issueToken(tokenKey(ctx, dbName, collName, indexName))
+	if err != nil {
+		return nil, fmt.Errorf("issue confirm token: %w", err)
+	}
+
+	return &IndexDetail{
+		IndexInfo:        *info,
+		ConfirmToken:     token.value,
+		ConfirmExpiresAt: token.expiresAt,
+	}, nil
+}
+
+func (m *Manager) CreateIndex(ctx context.Context, dbName, collName string, input CreateInput, actor Actor) (*Build, error) {
+	spec, name, err := buildSpec(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := m.repo.GetIndex(ctx, dbName, collName, name); err == nil {
+		return nil, core.DuplicateError("index " + name)
+	}
+
+	client, _ := mongodb.ClientFromContext(ctx)
+
+	build := &Build{
+		ID:         uuid.New().String(),
+		Database:   dbName,
+		Collection: collName,
+		Index:      name,
+		Spec:       specJSON(spec),
+		Status:     BuildRunning,
+		StartedAt:  time.Now(),
+	}
+	if client != nil {
+		build.Cluster = client.Name()
+	}
+
+	m.mu.Lock()
+	m.pruneBuilds()
+	m.builds[build.ID] = build
+	snapshot := *build
+	m.mu.Unlock()
+
+	go m.runBuild(client, build.ID, spec, actor)
+
+	m.logger.Info("index build started",
+		"id", build.ID,
+		"namespace", dbName+"."+collName,
+		"index", name,
+		"request_id", actor.RequestID,
+	)
+
+	return &snapshot, nil
+}
+
+func (m *Manager) GetBuild(ctx context.Context, id string) (*Build, error) {
+	m.mu.Lock()
+	build, ok := m.builds[id]
+	var snapshot Build
+	if ok {
+		snapshot = *build
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return nil, core.NotFoundError("index build")
+	}
+
+	if snapshot.Status == BuildRunning {
+		ops, err := m.repo.GetIndexBuilds(ctx, snapshot.Database+"."+snapshot.Collection)
+		if err != nil {
+			m.logger.Warn("failed to read index build progress", "id", id, "error", err)
+		}
+		for _, op := range ops {
+			for _, name := range op.Indexes {
+				if name == snapshot.Index {
+					snapshot.Progress = append(snapshot.Progress, op)
+					break
+				}
+			}
+		}
+	}
+
+	return &snapshot, nil
+}
+
+func (m *Manager) DropIndex(ctx context.Context, dbName, collName, indexName, token string, actor Actor) error {
+	if indexName == "_id_" {
+		return core.ValidationError("the _id index cannot be dropped")
+	}
+	if !m.consumeToken(tokenKey(ctx, dbName, collName, indexName), token) {
+		return core.ForbiddenError("invalid or expired confirmation token")
+	}
+
+	info, err := m.lookup(ctx, dbName, collName, indexName)
+	if err != nil {
+		return err
+	}
+
+	dropErr := m.repo.DropIndex(ctx, dbName, collName, indexName)
+	m.record(ctx, "index.drop", dbName, collName, indexName, map[string]any{"index": info}, actor, dropErr)
+	if dropErr != nil {
+		return commandError(dropErr)
+	}
+
+	m.logger.Info("index dropped",
+		"namespace", dbName+"."+collName,
+		"index", indexName,
+		"request_id", actor.RequestID,
+	)
+	return nil
+}
+
+func (m *Manager) SetHidden(ctx context.Context, dbName, collName, indexName string, hidden bool, token string, actor Actor) (*mongodb.IndexInfo, error) {
+	if indexName == "_id_" {
+		return nil, core.ValidationError("the _id index cannot be hidden")
+	}
+	if !m.consumeToken(tokenKey(ctx, dbName, collName, indexName), token) {
+		return nil, core.ForbiddenError("invalid or expired confirmation token")
+	}
+
+	info, err := m.lookup(ctx, dbName, collName, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	action := "index.unhide"
+	if hidden {
+		action = "index.hide"
+	}
+
+	modErr := m.repo.SetIndexHidden(ctx, dbName, collName, indexName, hidden)
+	m.record(ctx, action, dbName, collName, indexName, map[string]any{
+		"was_hidden": info.Hidden,
+		"hidden":     hidden,
+	}, actor, modErr)
+	if modErr != nil {
+		return nil, commandError(modErr)
+	}
+
+	return m.lookup(ctx, dbName, collName, indexName)
+}
+
+func (m *Manager) runBuild(client *mongodb.Client, id string, spec bson.D, actor Actor) {
+	m.mu.Lock()
+	build := *m.builds[id]
+	m.mu.Unlock()
+
+	ctx := context.Background()
+	if client != nil {
+		ctx = mongodb.WithClient(ctx, client)
+	}
+
+	buildErr := m.repo.CreateIndex(ctx, build.Database, build.Collection, spec)
+
+	finishedAt := time.Now()
+	m.mu.Lock()
+	if b, ok := m.builds[id]; ok {
+		b.FinishedAt = &finishedAt
+		b.Status = BuildCompleted
+		if buildErr != nil {
+			b.Status = BuildFailed
+			b.Error = buildErr.Error()
+		}
+	}
+	m.mu.Unlock()
+
+	m.record(ctx, "index.create", build.Database, build.Collection, build.Index, map[string]any{
+		"build_id":    id,
+		"spec":        build.Spec,
+		"duration_ms": finishedAt.Sub(build.StartedAt).Milliseconds(),
+	}, actor, buildErr)
+
+	if buildErr != nil {
+		m.logger.Error("index build failed", "id", id, "index", build.Index, "error", buildErr)
+		return
+	}
+	m.logger.Info("index build completed", "id", id, "index", build.Index, "duration", finishedAt.Sub(build.StartedAt))
+}
+
+func (m *Manager) lookup(ctx context.Context, dbName, collName, indexName string) (*mongodb.IndexInfo, error) {
+	info, err := m.repo.GetIndex(ctx, dbName, collName, indexName)
+	if errors.Is(err, mongodb.ErrIndexNotFound) {
+		return nil, core.NotFoundError("index")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get index: %w", err)
+	}
+	return info, nil
+}
+
+func (m *Manager) record(ctx context.Context, action, dbName, collName, indexName string, details map[string]any, actor Actor, opErr error) {
+	details["collection"] = collName
+	payload, _ := json.Marshal(details)
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       action,
+		Resource:     "index",
+		ResourceID:   dbName + "." + collName + "." + indexName,
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: dbName,
+		Details:      sql.NullString{String: string(payload), Valid: len(payload) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if opErr != nil {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: opErr.Error(), Valid: true}
+	}
+
+	if err := m.audit.Create(ctx, entry); err != nil {
+		m.logger.Error("failed to write audit entry", "action", action, "error", err)
+	}
+}
+
+func (m *Manager) pruneBuilds() {
+	cutoff := time.Now().Add(-buildRetention)
+	for id, b := range m.builds {
+		if b.FinishedAt != nil && b.FinishedAt.Before(cutoff) {
+			delete(m.builds, id)
+		}
+	}
+}
+
+func (m *Manager) issueToken(key string) (confirmToken, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return confirmToken{}, err
+	}
+
+	token := confirmToken{
+		value:     hex.EncodeToString(buf),
+		expiresAt: time.Now().Add(confirmTokenTTL),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range m.tokens {
+		if now.After(t.expiresAt) {
+			delete(m.tokens, k)
+		}
+	}
+	m.tokens[key] = token
+
+	return token, nil
+}
+
+func (m *Manager) consumeToken(key, value string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[key]
+	if !ok || value == "" {
+		return false
+	}
+	if time.Now().After(token.expiresAt) {
+		delete(m.tokens, key)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(token.value), []byte(value)) != 1 {
+		return false
+	}
+
+	delete(m.tokens, key)
+	return true
+}
+
+func tokenKey(ctx context.Context, dbName, collName, indexName string) string {
+	cluster := ""
+	if client, ok := mongodb.ClientFromContext(ctx); ok {
+		cluster = client.Name()
+	}
+	return cluster + "/" + dbName + "." + collName + "/" + indexName
+}
+
+func buildSpec(input CreateInput) (bson.D, string, error) {
+	keys, err := parseKeys(input.Keys)
+	if err != nil {
+		return nil, "", core.ValidationError("invalid keys: " + err.Error())
+	}
+	if len(keys) == 0 {
+		return nil, "", core.ValidationError("keys must contain at least one field")
+	}
+
+	var textIndex, wildcard bool
+	nameParts := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		switch v := k.Value.(type) {
+		case string:
+			if !indexKeyTypes[v] {
+				return nil, "", core.ValidationError(fmt.Sprintf("unsupported index type %q for field %s", v, k.Key))
+			}
+			textIndex = textIndex || v == "text"
+			nameParts = append(nameParts, k.Key, v)
+		case int32, int64, float64:
+			dir := fmt.Sprint(v)
+			if dir != "1" && dir != "-1" {
+				return nil, "", core.ValidationError(fmt.Sprintf("direction for field %s must be 1 or -1", k.Key))
+			}
+			nameParts = append(nameParts, k.Key, dir)
+		default:
+			return nil, "", core.ValidationError(fmt.Sprintf("invalid key value for field %s", k.Key))
+		}
+		if k.Key == "$**" || strings.HasSuffix(k.Key, ".$**") {
+			wildcard = true
+		}
+	}
+
+	name := input.Name
+	if name == "" {
+		name = strings.Join(nameParts, "_")
+	}
+
+	spec := bson.D{
+		{Key: "key", Value: keys},
+		{Key: "name", Value: name},
+	}
+
+	if input.Unique {
+		if wildcard || textIndex {
+			return nil, "", core.ValidationError("unique is not supported for text or wildcard indexes")
+		}
+		spec = append(spec, bson.E{Key: "unique", Value: true})
+	}
+	if input.Sparse {
+		spec = append(spec, bson.E{Key: "sparse", Value: true})
+	}
+	if input.Hidden {
+		spec = append(spec, bson.E{Key: "hidden", Value: true})
+	}
+	if input.ExpireAfterSeconds != nil {
+		if *input.ExpireAfterSeconds < 0 {
+			return nil, "", core.ValidationError("expire_after_seconds must not be negative")
+		}
+		if len(keys) != 1 || textIndex || wildcard {
+			return nil, "", core.ValidationError("TTL indexes require a single non-text, non-wildcard field")
+		}
+		spec = append(spec, bson.E{Key: "expireAfterSeconds", Value: *input.ExpireAfterSeconds})
+	}
+
+	for _, opt := range []struct {
+		key   string
+		value json.RawMessage
+	}{
+		{"partialFilterExpression", input.PartialFilterExpression},
+		{"collation", input.Collation},
+		{"weights", input.Weights},
+		{"wildcardProjection", input.WildcardProjection},
+	} {
+		if len(opt.value) == 0 || string(opt.value) == "null" {
+			continue
+		}
+		if opt.key == "weights" && !textIndex {
+			return nil, "", core.ValidationError("weights require a text index")
+		}
+		if opt.key == "wildcardProjection" && !(len(keys) == 1 && keys[0].Key == "$**") {
+			return nil, "", core.ValidationError("wildcard_projection requires a single $** key")
+		}
+		doc, err := mongodb.ParseExtJSONDocument(opt.value)
+		if err != nil {
+			return nil, "", core.ValidationError(fmt.Sprintf("invalid %s: %v", opt.key, err))
+		}
+		spec = append(spec, bson.E{Key: opt.key, Value: doc})
+	}
+
+	if input.DefaultLanguage != "" || input.LanguageOverride != "" {
+		if !textIndex {
+			return nil, "", core.ValidationError("default_language and language_override require a text index")
+		}
+		if input.DefaultLanguage != "" {
+			spec = append(spec, bson.E{Key: "default_language", Value: input.DefaultLanguage})
+		}
+		if input.LanguageOverride != "" {
+			spec = append(spec, bson.E{Key: "language_override", Value: input.LanguageOverride})
+		}
+	}
+
+	return spec, name, nil
+}
+
+func parseKeys(data json.RawMessage) (bson.D, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return mongodb.ParseExtJSONDocument(data)
+	}
+
+	var fields []mongodb.IndexKeyField
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, err
+	}
+
+	keys := make(bson.D, 0, len(fields))
+	for _, f := range fields {
+		if f.Field == "" {
+			return nil, errors.New("every key requires a field")
+		}
+
+		var value any
+		switch {
+		case len(f.Value) > 0:
+			v, err := mongodb.ParseExtJSONValue(f.Value)
+			if err != nil {
+				return nil, err
+			}
+			if err := v.Unmarshal(&value); err != nil {
+				return nil, err
+			}
+		case f.Type == mongodb.IndexKeyDescending || f.Direction < 0:
+			value = int32(-1)
+		case f.Type == "" || f.Type == mongodb.IndexKeyAscending || f.Type == mongodb.IndexKeyWildcard:
+			value = int32(1)
+		default:
+			value = f.Type
+		}
+		keys = append(keys, bson.E{Key: f.Field, Value: value})
+	}
+	return keys, nil
+}
+
+func specJSON(spec bson.D) json.RawMessage {
+	out, err := bson.MarshalExtJSON(spec, false, false)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func commandError(err error) error {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return core.ValidationError(cmdErr.Message)
+	}
+	return err
+}
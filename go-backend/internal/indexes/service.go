@@ -0,0 +1,294 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package indexes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	FindingUnused    = "unused"
+	FindingRedundant = "redundant"
+	FindingDuplicate = "duplicate"
+
+	defaultUnusedWindow = 30 * 24 * time.Hour
+)
+
+type indexRepository interface {
+	ListCollections(ctx context.Context, dbName string) ([]mongodb.CollectionInfo, error)
+	GetIndexes(ctx context.Context, dbName, collName string) ([]mongodb.IndexInfo, error)
+	GetIndexStats(ctx context.Context, dbName, collName string) (map[string]mongodb.IndexUsage, error)
+}
+
+type Service struct {
+	repo indexRepository
+}
+
+func NewService(repo indexRepository) *Service {
+	return &Service{repo: repo}
+}
+
+type UsageReport struct {
+	Database         string    `json:"database"`
+	GeneratedAt      time.Time `json:"generated_at"`
+	UnusedSince      time.Time `json:"unused_since"`
+	CollectionCount  int       `json:"collection_count"`
+	IndexCount       int       `json:"index_count"`
+	Findings         []Finding `json:"findings"`
+	ReclaimableBytes int64     `json:"reclaimable_bytes"`
+	Errors           []string  `json:"errors,omitempty"`
+}
+
+type Finding struct {
+	Collection   string                  `json:"collection"`
+	Index        string                  `json:"index"`
+	Kind         string                  `json:"kind"`
+	Keys         []mongodb.IndexKeyField `json:"keys"`
+	CoveredBy    string                  `json:"covered_by,omitempty"`
+	Ops          int64                   `json:"ops"`
+	TrackedSince *time.Time              `json:"tracked_since,omitempty"`
+	ReclaimBytes int64                   `json:"reclaim_bytes"`
+	Reason       string                  `json:"reason"`
+}
+
+type keyPart struct {
+	field string
+	value string
+}
+
+type indexSpec struct {
+	info      mongodb.IndexInfo
+	keys      []keyPart
+	btree     bool
+	unique    bool
+	sparse    bool
+	partial   bson.Raw
+	collation bson.Raw
+	ttl       bool
+	hidden    bool
+}
+
+func (s *Service) UsageReport(ctx context.Context, dbName string, unusedSince time.Time) (*UsageReport, error) {
+	now := time.Now()
+	if unusedSince.IsZero() {
+		unusedSince = now.Add(-defaultUnusedWindow)
+	}
+
+	collections, err := s.repo.ListCollections(ctx, dbName)
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+
+	report := &UsageReport{
+		Database:    dbName,
+		GeneratedAt: now,
+		UnusedSince: unusedSince,
+		Findings:    make([]Finding, 0),
+	}
+
+	reclaimed := make(map[string]bool)
+	for _, coll := range collections {
+		if coll.Type == "view" || strings.HasPrefix(coll.Name, "system.") {
+			continue
+		}
+		report.CollectionCount++
+
+		indexes, err := s.repo.GetIndexes(ctx, dbName, coll.Name)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", coll.Name, err))
+			continue
+		}
+		report.IndexCount += len(indexes)
+
+		usage, err := s.repo.GetIndexStats(ctx, dbName, coll.Name)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: index usage unavailable, unused indexes not evaluated: %v", coll.Name, err))
+		}
+		for i := range indexes {
+			if u, ok := usage[indexes[i].Name]; ok {
+				indexes[i].Usage = &u
+			}
+		}
+
+		for _, f := range analyzeCollection(coll.Name, indexes, unusedSince) {
+			key := f.Collection + "." + f.Index
+			if !reclaimed[key] {
+				reclaimed[key] = true
+				report.ReclaimableBytes += f.ReclaimBytes
+			}
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].ReclaimBytes > report.Findings[j].ReclaimBytes
+	})
+
+	return report, nil
+}
+
+func analyzeCollection(collName string, indexes []mongodb.IndexInfo, unusedSince time.Time) []Finding {
+	specs := make([]indexSpec, 0, len(indexes))
+	for _, idx := range indexes {
+		specs = append(specs, parseSpec(idx))
+	}
+
+	var findings []Finding
+	flagged := make(map[string]bool)
+
+	for i, a := range specs {
+		if a.info.Name == "_id_" {
+			continue
+		}
+
+		for j, b := range specs {
+			if i == j || !sameKeys(a.keys, b.keys) || !sameOptions(a, b) {
+				continue
+			}
+			if preferKeep(a, b, i, j) {
+				continue
+			}
+			findings = append(findings, newFinding(collName, a, FindingDuplicate, b.info.Name,
+				fmt.Sprintf("same key pattern and options as %s", b.info.Name)))
+			flagged[a.info.Name] = true
+			break
+		}
+		if flagged[a.info.Name] {
+			continue
+		}
+
+		for _, b := range specs {
+			if a.info.Name == b.info.Name || !isPrefix(a, b) {
+				continue
+			}
+			findings = append(findings, newFinding(collName, a, FindingRedundant, b.info.Name,
+				fmt.Sprintf("key pattern is a prefix of %s, which can serve the same queries", b.info.Name)))
+			break
+		}
+	}
+
+	for _, a := range specs {
+		if a.info.Name == "_id_" || a.info.Usage == nil {
+			continue
+		}
+		usage := a.info.Usage
+		if usage.Ops > 0 || usage.Since.IsZero() || usage.Since.After(unusedSince) {
+			continue
+		}
+		findings = append(findings, newFinding(collName, a, FindingUnused, "",
+			fmt.Sprintf("no accesses recorded since %s", usage.Since.Format(time.RFC3339))))
+	}
+
+	return findings
+}
+
+func newFinding(collName string, spec indexSpec, kind, coveredBy, reason string) Finding {
+	f := Finding{
+		Collection:   collName,
+		Index:        spec.info.Name,
+		Kind:         kind,
+		Keys:         spec.info.Keys,
+		CoveredBy:    coveredBy,
+		ReclaimBytes: spec.info.SizeBytes,
+		Reason:       reason,
+	}
+	if spec.info.Usage != nil {
+		since := spec.info.Usage.Since
+		f.Ops = spec.info.Usage.Ops
+		f.TrackedSince = &since
+	}
+	return f
+}
+
+func parseSpec(idx mongodb.IndexInfo) indexSpec {
+	spec := indexSpec{
+		info:   idx,
+		btree:  true,
+		unique: idx.Unique,
+		sparse: idx.Sparse,
+		hidden: idx.Hidden,
+		ttl:    idx.ExpireAfterSeconds != nil,
+	}
+	raw := idx.Spec
+
+	for _, key := range idx.Keys {
+		switch key.Type {
+		case mongodb.IndexKeyAscending, mongodb.IndexKeyDescending:
+		default:
+			spec.btree = false
+		}
+		spec.keys = append(spec.keys, keyPart{field: key.Field, value: key.Type})
+	}
+
+	if v, err := raw.LookupErr("partialFilterExpression"); err == nil {
+		spec.partial = v.Value
+	}
+	if v, err := raw.LookupErr("collation"); err == nil {
+		spec.collation = v.Value
+	}
+
+	return spec
+}
+
+func sameKeys(a, b []keyPart) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameOptions(a, b indexSpec) bool {
+	return a.unique == b.unique &&
+		a.sparse == b.sparse &&
+		a.ttl == b.ttl &&
+		bytes.Equal(a.partial, b.partial) &&
+		bytes.Equal(a.collation, b.collation)
+}
+
+func preferKeep(a, b indexSpec, i, j int) bool {
+	if b.info.Name == "_id_" {
+		return false
+	}
+	if a.hidden != b.hidden {
+		return !a.hidden
+	}
+	return i < j
+}
+
+func isPrefix(a, b indexSpec) bool {
+	if !a.btree || !b.btree || len(a.keys) >= len(b.keys) {
+		return false
+	}
+	if a.unique || a.ttl || a.sparse || len(a.partial) > 0 {
+		return false
+	}
+	if b.sparse || len(b.partial) > 0 || b.hidden {
+		return false
+	}
+	if !bytes.Equal(a.collation, b.collation) {
+		return false
+	}
+	for i := range a.keys {
+		if a.keys[i] != b.keys[i] {
+			return false
+		}
+	}
+	return true
+}
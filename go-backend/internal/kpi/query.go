@@ -0,0 +1,102 @@
+/*
+AngelaMos | 2026
+query.go
+*/
+
+package kpi
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+type query struct {
+	filter   bson.D
+	pipeline mongo.Pipeline
+}
+
+func compile(def Definition) (*query, error) {
+	exclusions, err := exclusionFilter(def.Exclusions)
+	if err != nil {
+		return nil, err
+	}
+
+	switch def.Type {
+	case TypeCount:
+		filter, err := mongodb.ParseExtJSONDocument(def.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		return &query{filter: mergeFilters(filter, exclusions)}, nil
+
+	case TypeAggregate:
+		if len(def.Pipeline) == 0 {
+			return nil, errors.New("pipeline is required for aggregate kpis")
+		}
+
+		stages, err := mongodb.ParseExtJSONPipeline(def.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline: %w", err)
+		}
+
+		pipeline := make(mongo.Pipeline, 0, len(stages)+1)
+		if len(exclusions) > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$match", Value: exclusions}})
+		}
+		pipeline = append(pipeline, stages...)
+		return &query{pipeline: pipeline}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported kpi type %q", def.Type)
+}
+
+func exclusionFilter(exclusions []Exclusion) (bson.D, error) {
+	var fields []string
+	values := make(map[string]bson.A)
+	for _, e := range exclusions {
+		if e.Field == "" {
+			return nil, errors.New("exclusions require a field")
+		}
+		for _, raw := range e.Values {
+			v, err := mongodb.ParseExtJSONValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exclusion value for %s: %w", e.Field, err)
+			}
+			if _, ok := values[e.Field]; !ok {
+				fields = append(fields, e.Field)
+			}
+			values[e.Field] = append(values[e.Field], v)
+		}
+	}
+
+	filter := bson.D{}
+	for _, field := range fields {
+		filter = append(filter, bson.E{Key: field, Value: bson.D{{Key: "$nin", Value: values[field]}}})
+	}
+	return filter, nil
+}
+
+func mergeFilters(filter, exclusions bson.D) bson.D {
+	if len(exclusions) == 0 {
+		return filter
+	}
+	if len(filter) == 0 {
+		return exclusions
+	}
+
+	keys := make(map[string]bool, len(filter))
+	for _, e := range filter {
+		keys[e.Key] = true
+	}
+	for _, e := range exclusions {
+		if keys[e.Key] {
+			return bson.D{{Key: "$and", Value: bson.A{filter, exclusions}}}
+		}
+	}
+	return append(filter, exclusions...)
+}
@@ -0,0 +1,507 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package kpi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	TypeCount     = "count"
+	TypeAggregate = "aggregate"
+
+	SourceConfig = "config"
+	SourceStored = "sqlite"
+
+	evaluateTimeout = 30 * time.Second
+)
+
+var kpiNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,62}$`)
+
+type evaluator interface {
+	Count(ctx context.Context, dbName, collName string, filter bson.D) (int64, error)
+	Aggregate(ctx context.Context, dbName, collName string, pipeline mongo.Pipeline) (float64, error)
+}
+
+type kpiRepository interface {
+	CreateDefinition(ctx context.Context, d *sqlite.KPIDefinition) error
+	ListDefinitions(ctx context.Context) ([]*sqlite.KPIDefinition, error)
+	DeleteDefinition(ctx context.Context, name string) error
+	InsertSample(ctx context.Context, s *sqlite.KPISample) error
+	ListSamples(ctx context.Context, name string, since time.Time, limit int) ([]*sqlite.KPISample, error)
+	DeleteSamplesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type clusterResolver interface {
+	Get(name string) (*mongodb.Client, error)
+}
+
+type Service struct {
+	evaluator     evaluator
+	repo          kpiRepository
+	clusters      clusterResolver
+	configured    []Definition
+	interval      time.Duration
+	retentionDays int
+	latest        map[string]Value
+	mu            sync.RWMutex
+	logger        *slog.Logger
+}
+
+type Definition struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Cluster    string          `json:"cluster,omitempty"`
+	Database   string          `json:"database,omitempty"`
+	Collection string          `json:"collection"`
+	Type       string          `json:"type"`
+	Filter     json.RawMessage `json:"filter,omitempty"`
+	Pipeline   json.RawMessage `json:"pipeline,omitempty"`
+	Exclusions []Exclusion     `json:"exclusions,omitempty"`
+	Source     string          `json:"source"`
+	Latest     *Value          `json:"latest,omitempty"`
+}
+
+type Exclusion struct {
+	Field  string            `json:"field"`
+	Values []json.RawMessage `json:"values"`
+}
+
+type Value struct {
+	Name      string    `json:"name"`
+	Label     string    `json:"label"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Value     float64   `json:"value"`
+	SampledAt time.Time `json:"sampled_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type Sample struct {
+	Value     float64   `json:"value"`
+	SampledAt time.Time `json:"sampled_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type CreateInput struct {
+	Name       string
+	Label      string
+	Cluster    string
+	Database   string
+	Collection string
+	Type       string
+	Filter     json.RawMessage
+	Pipeline   json.RawMessage
+	Exclusions []Exclusion
+}
+
+func NewService(evaluator evaluator, repo kpiRepository, clusters clusterResolver, cfg config.KPIConfig, logger *slog.Logger) *Service {
+	s := &Service{
+		evaluator:     evaluator,
+		repo:          repo,
+		clusters:      clusters,
+		interval:      cfg.Interval,
+		retentionDays: cfg.RetentionDays,
+		latest:        make(map[string]Value),
+		logger:        logger,
+	}
+
+	for _, d := range cfg.Definitions {
+		def := fromConfig(d)
+		if _, err := compile(def); err != nil {
+			logger.Warn("skipping invalid kpi definition", "kpi", d.Name, "error", err)
+			continue
+		}
+		s.configured = append(s.configured, def)
+	}
+
+	return s
+}
+
+func (s *Service) Start(ctx context.Context) {
+	go func() {
+		s.EvaluateAll(ctx)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.EvaluateAll(ctx)
+				s.prune(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Service) EvaluateAll(ctx context.Context) {
+	definitions, err := s.definitions(ctx)
+	if err != nil {
+		s.logger.Error("failed to load kpi definitions", "error", err)
+		return
+	}
+
+	for _, def := range definitions {
+		s.record(ctx, def, s.evaluate(ctx, def))
+	}
+}
+
+func (s *Service) Latest(clusterName string) map[string]Value {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	latest := make(map[string]Value, len(s.latest))
+	for name, value := range s.latest {
+		client, err := s.clusters.Get(value.Cluster)
+		if err != nil || client.Name() != clusterName {
+			continue
+		}
+		latest[name] = value
+	}
+	return latest
+}
+
+func (s *Service) List(ctx context.Context) ([]Definition, error) {
+	definitions, err := s.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range definitions {
+		if value, ok := s.latest[definitions[i].Name]; ok {
+			definitions[i].Latest = &value
+		}
+	}
+	return definitions, nil
+}
+
+func (s *Service) Create(ctx context.Context, input CreateInput) (*Definition, error) {
+	if !kpiNamePattern.MatchString(input.Name) {
+		return nil, core.ValidationError("name must be 1-63 characters of letters, digits, '-' or '_'")
+	}
+	if input.Collection == "" {
+		return nil, core.ValidationError("collection is required")
+	}
+	if input.Type == "" {
+		input.Type = TypeCount
+	}
+	if _, err := s.clusters.Get(input.Cluster); err != nil {
+		return nil, core.ValidationError("unknown cluster " + input.Cluster)
+	}
+
+	existing, err := s.find(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, core.DuplicateError("kpi " + input.Name)
+	}
+
+	def := Definition{
+		Name:       input.Name,
+		Label:      input.Label,
+		Cluster:    input.Cluster,
+		Database:   input.Database,
+		Collection: input.Collection,
+		Type:       input.Type,
+		Filter:     input.Filter,
+		Pipeline:   input.Pipeline,
+		Exclusions: input.Exclusions,
+		Source:     SourceStored,
+	}
+	if def.Label == "" {
+		def.Label = def.Name
+	}
+
+	if _, err := compile(def); err != nil {
+		return nil, core.ValidationError(err.Error())
+	}
+
+	exclusions, err := json.Marshal(def.Exclusions)
+	if err != nil {
+		return nil, fmt.Errorf("encode exclusions: %w", err)
+	}
+
+	now := time.Now()
+	err = s.repo.CreateDefinition(ctx, &sqlite.KPIDefinition{
+		Name:           def.Name,
+		Label:          def.Label,
+		ClusterName:    def.Cluster,
+		DatabaseName:   def.Database,
+		CollectionName: def.Collection,
+		Kind:           def.Type,
+		Filter:         string(def.Filter),
+		Pipeline:       string(def.Pipeline),
+		Exclusions:     string(exclusions),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("persist kpi: %w", err)
+	}
+
+	value := s.evaluate(ctx, def)
+	s.record(ctx, def, value)
+	def.Latest = &value
+
+	return &def, nil
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	def, err := s.find(ctx, name)
+	if err != nil {
+		return err
+	}
+	if def == nil {
+		return core.NotFoundError("kpi")
+	}
+	if def.Source != SourceStored {
+		return core.ForbiddenError("kpis defined in config cannot be removed through the API")
+	}
+
+	if err := s.repo.DeleteDefinition(ctx, name); err != nil {
+		return fmt.Errorf("delete kpi: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.latest, name)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Service) History(ctx context.Context, name string, window time.Duration, limit int) ([]Sample, error) {
+	def, err := s.find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, core.NotFoundError("kpi")
+	}
+
+	records, err := s.repo.ListSamples(ctx, name, time.Now().Add(-window), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list kpi samples: %w", err)
+	}
+
+	samples := make([]Sample, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		samples = append(samples, Sample{
+			Value:     records[i].Value,
+			SampledAt: records[i].SampledAt,
+			Error:     records[i].ErrorMessage.String,
+		})
+	}
+	return samples, nil
+}
+
+func (s *Service) evaluate(ctx context.Context, def Definition) Value {
+	value := Value{
+		Name:      def.Name,
+		Label:     def.Label,
+		Cluster:   def.Cluster,
+		SampledAt: time.Now(),
+	}
+
+	result, err := s.run(ctx, def)
+	if err != nil {
+		value.Error = err.Error()
+		return value
+	}
+
+	value.Value = result
+	return value
+}
+
+func (s *Service) run(ctx context.Context, def Definition) (float64, error) {
+	client, err := s.clusters.Get(def.Cluster)
+	if err != nil {
+		return 0, fmt.Errorf("resolve cluster %q: %w", def.Cluster, err)
+	}
+
+	q, err := compile(def)
+	if err != nil {
+		return 0, err
+	}
+
+	dbName := def.Database
+	if dbName == "" {
+		dbName = client.DefaultDatabase()
+	}
+
+	evalCtx, cancel := context.WithTimeout(mongodb.WithClient(ctx, client), evaluateTimeout)
+	defer cancel()
+
+	if def.Type == TypeAggregate {
+		return s.evaluator.Aggregate(evalCtx, dbName, def.Collection, q.pipeline)
+	}
+
+	count, err := s.evaluator.Count(evalCtx, dbName, def.Collection, q.filter)
+	return float64(count), err
+}
+
+func (s *Service) record(ctx context.Context, def Definition, value Value) {
+	if value.Error != "" {
+		s.logger.Warn("kpi evaluation failed", "kpi", def.Name, "error", value.Error)
+	}
+
+	s.mu.Lock()
+	s.latest[def.Name] = value
+	s.mu.Unlock()
+
+	sample := &sqlite.KPISample{
+		KPIName:     def.Name,
+		ClusterName: def.Cluster,
+		Value:       value.Value,
+		SampledAt:   value.SampledAt,
+	}
+	if value.Error != "" {
+		sample.ErrorMessage = sql.NullString{String: value.Error, Valid: true}
+	}
+
+	if err := s.repo.InsertSample(ctx, sample); err != nil {
+		s.logger.Error("failed to store kpi sample", "kpi", def.Name, "error", err)
+	}
+}
+
+func (s *Service) prune(ctx context.Context) {
+	if s.retentionDays <= 0 {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+	if _, err := s.repo.DeleteSamplesBefore(ctx, cutoff); err != nil {
+		s.logger.Error("failed to prune kpi samples", "error", err)
+	}
+}
+
+func (s *Service) definitions(ctx context.Context) ([]Definition, error) {
+	stored, err := s.repo.ListDefinitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list kpi definitions: %w", err)
+	}
+
+	definitions := make([]Definition, 0, len(s.configured)+len(stored))
+	definitions = append(definitions, s.configured...)
+
+	seen := make(map[string]bool, len(s.configured))
+	for _, def := range s.configured {
+		seen[def.Name] = true
+	}
+
+	for _, record := range stored {
+		if seen[record.Name] {
+			continue
+		}
+		def, err := fromRecord(record)
+		if err != nil {
+			s.logger.Warn("skipping invalid kpi definition", "kpi", record.Name, "error", err)
+			continue
+		}
+		definitions = append(definitions, def)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+
+	return definitions, nil
+}
+
+func (s *Service) find(ctx context.Context, name string) (*Definition, error) {
+	definitions, err := s.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range definitions {
+		if definitions[i].Name == name {
+			return &definitions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func fromConfig(d config.KPIDefinition) Definition {
+	def := Definition{
+		Name:       d.Name,
+		Label:      d.Label,
+		Cluster:    d.Cluster,
+		Database:   d.Database,
+		Collection: d.Collection,
+		Type:       d.Type,
+		Source:     SourceConfig,
+	}
+	if def.Label == "" {
+		def.Label = def.Name
+	}
+	if def.Type == "" {
+		def.Type = TypeCount
+	}
+	if d.Filter != "" {
+		def.Filter = json.RawMessage(d.Filter)
+	}
+	if d.Pipeline != "" {
+		def.Pipeline = json.RawMessage(d.Pipeline)
+	}
+	for _, e := range d.Exclusions {
+		exclusion := Exclusion{Field: e.Field}
+		for _, v := range e.Values {
+			raw, err := json.Marshal(v)
+			if err != nil {
+				raw = json.RawMessage("null")
+			}
+			exclusion.Values = append(exclusion.Values, raw)
+		}
+		def.Exclusions = append(def.Exclusions, exclusion)
+	}
+	return def
+}
+
+func fromRecord(r *sqlite.KPIDefinition) (Definition, error) {
+	def := Definition{
+		Name:       r.Name,
+		Label:      r.Label,
+		Cluster:    r.ClusterName,
+		Database:   r.DatabaseName,
+		Collection: r.CollectionName,
+		Type:       r.Kind,
+		Source:     SourceStored,
+	}
+	if r.Filter != "" {
+		def.Filter = json.RawMessage(r.Filter)
+	}
+	if r.Pipeline != "" {
+		def.Pipeline = json.RawMessage(r.Pipeline)
+	}
+	if r.Exclusions != "" {
+		if err := json.Unmarshal([]byte(r.Exclusions), &def.Exclusions); err != nil {
+			return def, fmt.Errorf("decode exclusions: %w", err)
+		}
+	}
+	return def, nil
+}
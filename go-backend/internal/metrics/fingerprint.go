@@ -0,0 +1,391 @@
+/*
+AngelaMos | 2026
+fingerprint.go
+*/
+
+package metrics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+type QueryShapeReport struct {
+	Database        string       `json:"database"`
+	AnalyzedQueries int          `json:"analyzed_queries"`
+	ShapeCount      int          `json:"shape_count"`
+	SortBy          string       `json:"sort_by"`
+	Shapes          []QueryShape `json:"shapes"`
+}
+
+type QueryShape struct {
+	Fingerprint             string          `json:"fingerprint"`
+	Namespace               string          `json:"namespace"`
+	Operation               string          `json:"operation"`
+	Shape                   json.RawMessage `json:"shape"`
+	Count                   int             `json:"count"`
+	TotalMillis             int64           `json:"total_millis"`
+	AvgMillis               float64         `json:"avg_millis"`
+	P50Millis               int             `json:"p50_millis"`
+	P95Millis               int             `json:"p95_millis"`
+	MaxMillis               int             `json:"max_millis"`
+	DocsExamined            int64           `json:"docs_examined"`
+	KeysExamined            int64           `json:"keys_examined"`
+	Returned                int64           `json:"returned"`
+	DocsExaminedPerReturned float64         `json:"docs_examined_per_returned"`
+	KeysExaminedPerReturned float64         `json:"keys_examined_per_returned"`
+	PlanSummaries           []string        `json:"plan_summaries"`
+	FirstSeen               time.Time       `json:"first_seen"`
+	LastSeen                time.Time       `json:"last_seen"`
+}
+
+type shapeAggregator struct {
+	shape    QueryShape
+	millis   []int
+	plans    map[string]bool
+	template bson.D
+}
+
+var shapeFields = []struct {
+	field string
+	label string
+	spec  bool
+}{
+	{"filter", "filter", false},
+	{"query", "filter", false},
+	{"q", "filter", false},
+	{"pipeline", "pipeline", false},
+	{"key", "key", true},
+	{"sort", "sort", true},
+	{"projection", "projection", true},
+	{"fields", "projection", true},
+	{"u", "update", false},
+	{"update", "update", false},
+	{"remove", "remove", true},
+	{"hint", "hint", true},
+}
+
+var specStages = map[string]bool{
+	"$sort":    true,
+	"$project": true,
+}
+
+func (s *Service) GetQueryShapes(ctx context.Context, dbName string, minMillis, sample int, sortBy string, limit int) (*QueryShapeReport, error) {
+	dbName = s.resolveDatabase(ctx, dbName)
+
+	queries, err := s.repo.GetSlowQueries(ctx, dbName, minMillis, sample)
+	if err != nil {
+		return nil, fmt.Errorf("get slow queries: %w", err)
+	}
+
+	shapes := groupQueryShapes(queries)
+	sortBy = sortQueryShapes(shapes, sortBy)
+
+	report := &QueryShapeReport{
+		Database:        dbName,
+		AnalyzedQueries: len(queries),
+		ShapeCount:      len(shapes),
+		SortBy:          sortBy,
+		Shapes:          shapes,
+	}
+	if limit > 0 && len(report.Shapes) > limit {
+		report.Shapes = report.Shapes[:limit]
+	}
+
+	return report, nil
+}
+
+func groupQueryShapes(queries []mongodb.SlowQuery) []QueryShape {
+	aggregators := make(map[string]*shapeAggregator)
+	order := make([]string, 0)
+
+	for _, q := range queries {
+		template := queryShape(q)
+		fingerprint := fingerprintShape(q.Namespace, q.Op, template)
+
+		agg, ok := aggregators[fingerprint]
+		if !ok {
+			agg = &shapeAggregator{
+				shape: QueryShape{
+					Fingerprint: fingerprint,
+					Namespace:   q.Namespace,
+					Operation:   q.Op,
+					FirstSeen:   q.Timestamp,
+					LastSeen:    q.Timestamp,
+				},
+				plans:    make(map[string]bool),
+				template: template,
+			}
+			aggregators[fingerprint] = agg
+			order = append(order, fingerprint)
+		}
+
+		agg.add(q)
+	}
+
+	shapes := make([]QueryShape, 0, len(aggregators))
+	for _, fingerprint := range order {
+		shapes = append(shapes, aggregators[fingerprint].result())
+	}
+	return shapes
+}
+
+func (a *shapeAggregator) add(q mongodb.SlowQuery) {
+	a.shape.Count++
+	a.shape.TotalMillis += int64(q.MillisRuntime)
+	a.shape.DocsExamined += q.DocsExamined
+	a.shape.KeysExamined += q.KeysExamined
+	a.shape.Returned += q.NReturned
+	a.millis = append(a.millis, q.MillisRuntime)
+
+	if q.PlanSummary != "" {
+		a.plans[q.PlanSummary] = true
+	}
+	if q.Timestamp.Before(a.shape.FirstSeen) {
+		a.shape.FirstSeen = q.Timestamp
+	}
+	if q.Timestamp.After(a.shape.LastSeen) {
+		a.shape.LastSeen = q.Timestamp
+	}
+}
+
+func (a *shapeAggregator) result() QueryShape {
+	shape := a.shape
+
+	sort.Ints(a.millis)
+	shape.P50Millis = percentile(a.millis, 0.50)
+	shape.P95Millis = percentile(a.millis, 0.95)
+	shape.MaxMillis = a.millis[len(a.millis)-1]
+	shape.AvgMillis = float64(shape.TotalMillis) / float64(shape.Count)
+
+	returned := float64(shape.Returned)
+	if returned == 0 {
+		returned = 1
+	}
+	shape.DocsExaminedPerReturned = float64(shape.DocsExamined) / returned
+	shape.KeysExaminedPerReturned = float64(shape.KeysExamined) / returned
+
+	shape.PlanSummaries = make([]string, 0, len(a.plans))
+	for plan := range a.plans {
+		shape.PlanSummaries = append(shape.PlanSummaries, plan)
+	}
+	sort.Strings(shape.PlanSummaries)
+
+	if out, err := bson.MarshalExtJSON(a.template, false, false); err == nil {
+		shape.Shape = out
+	}
+
+	return shape
+}
+
+func sortQueryShapes(shapes []QueryShape, sortBy string) string {
+	switch sortBy {
+	case "count":
+		sort.SliceStable(shapes, func(i, j int) bool {
+			return shapes[i].Count > shapes[j].Count
+		})
+	case "p95":
+		sort.SliceStable(shapes, func(i, j int) bool {
+			return shapes[i].P95Millis > shapes[j].P95Millis
+		})
+	case "max":
+		sort.SliceStable(shapes, func(i, j int) bool {
+			return shapes[i].MaxMillis > shapes[j].MaxMillis
+		})
+	default:
+		sortBy = "total"
+		sort.SliceStable(shapes, func(i, j int) bool {
+			return shapes[i].TotalMillis > shapes[j].TotalMillis
+		})
+	}
+	return sortBy
+}
+
+func percentile(sorted []int, p float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func profiledCommand(q mongodb.SlowQuery) bson.Raw {
+	cmd := q.Command
+	if q.Op == "getmore" && len(q.Originating) > 0 {
+		cmd = q.Originating
+	}
+	if len(cmd) == 0 {
+		cmd = q.Query
+	}
+	return cmd
+}
+
+func queryShape(q mongodb.SlowQuery) bson.D {
+	cmd := profiledCommand(q)
+
+	shape := bson.D{}
+	if len(cmd) == 0 {
+		return shape
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range shapeFields {
+		if seen[f.label] {
+			continue
+		}
+
+		value, err := cmd.LookupErr(f.field)
+		if err != nil {
+			continue
+		}
+		seen[f.label] = true
+
+		if f.spec {
+			shape = append(shape, bson.E{Key: f.label, Value: normalizeSpec(value)})
+		} else {
+			shape = append(shape, bson.E{Key: f.label, Value: normalizeValue(value)})
+		}
+	}
+
+	return shape
+}
+
+func fingerprintShape(namespace, op string, shape bson.D) string {
+	out, err := bson.MarshalExtJSON(shape, true, false)
+	if err != nil {
+		out = nil
+	}
+
+	sum := sha256.Sum256([]byte(namespace + "\x00" + op + "\x00" + string(out)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func normalizeValue(v bson.RawValue) any {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		return normalizeDocument(v.Document())
+	case bson.TypeArray:
+		return normalizeArray(v.Array())
+	}
+	return bsonTypeName(v.Type)
+}
+
+func normalizeDocument(doc bson.Raw) bson.D {
+	elements, err := doc.Elements()
+	if err != nil {
+		return bson.D{}
+	}
+
+	out := make(bson.D, 0, len(elements))
+	for _, e := range elements {
+		key := e.Key()
+		if specStages[key] {
+			out = append(out, bson.E{Key: key, Value: normalizeSpec(e.Value())})
+			continue
+		}
+		out = append(out, bson.E{Key: key, Value: normalizeValue(e.Value())})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func normalizeArray(arr bson.RawArray) bson.A {
+	values, err := arr.Values()
+	if err != nil {
+		return bson.A{}
+	}
+
+	out := bson.A{}
+	scalars := make(map[string]bool)
+	for _, v := range values {
+		if v.Type == bson.TypeEmbeddedDocument || v.Type == bson.TypeArray {
+			out = append(out, normalizeValue(v))
+			continue
+		}
+		scalars[bsonTypeName(v.Type)] = true
+	}
+
+	names := make([]string, 0, len(scalars))
+	for name := range scalars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out = append(out, name)
+	}
+
+	return out
+}
+
+func normalizeSpec(v bson.RawValue) any {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		elements, err := v.Document().Elements()
+		if err != nil {
+			return bson.D{}
+		}
+		out := make(bson.D, 0, len(elements))
+		for _, e := range elements {
+			out = append(out, bson.E{Key: e.Key(), Value: normalizeSpec(e.Value())})
+		}
+		return out
+	case bson.TypeInt32, bson.TypeInt64, bson.TypeDouble:
+		if n, ok := v.AsInt64OK(); ok {
+			return n
+		}
+		return v.Double()
+	case bson.TypeBoolean:
+		return v.Boolean()
+	case bson.TypeString:
+		return v.StringValue()
+	}
+	return normalizeValue(v)
+}
+
+func bsonTypeName(t bson.Type) string {
+	switch t {
+	case bson.TypeDouble, bson.TypeInt32, bson.TypeInt64, bson.TypeDecimal128:
+		return "number"
+	case bson.TypeString, bson.TypeSymbol:
+		return "string"
+	case bson.TypeBoolean:
+		return "bool"
+	case bson.TypeDateTime:
+		return "date"
+	case bson.TypeTimestamp:
+		return "timestamp"
+	case bson.TypeObjectID:
+		return "objectId"
+	case bson.TypeNull:
+		return "null"
+	case bson.TypeUndefined:
+		return "undefined"
+	case bson.TypeRegex:
+		return "regex"
+	case bson.TypeBinary:
+		return "binData"
+	case bson.TypeJavaScript, bson.TypeCodeWithScope:
+		return "javascript"
+	case bson.TypeMinKey:
+		return "minKey"
+	case bson.TypeMaxKey:
+		return "maxKey"
+	}
+	return t.String()
+}
@@ -0,0 +1,523 @@
+/*
+AngelaMos | 2026
+recommend.go
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	minDocsExaminedForIndex = 100
+	minExaminedPerReturned  = 10.0
+	maxIndexKeys            = 32
+)
+
+const (
+	keyRoleEquality = "equality"
+	keyRoleSort     = "sort"
+	keyRoleRange    = "range"
+)
+
+var equalityOperators = map[string]bool{
+	"$eq":        true,
+	"$in":        true,
+	"$elemMatch": true,
+	"$all":       true,
+	"$size":      true,
+}
+
+var rangeOperators = map[string]bool{
+	"$gt":     true,
+	"$gte":    true,
+	"$lt":     true,
+	"$lte":    true,
+	"$ne":     true,
+	"$nin":    true,
+	"$regex":  true,
+	"$exists": true,
+	"$type":   true,
+	"$mod":    true,
+}
+
+type IndexKey struct {
+	Field     string `json:"field"`
+	Direction int    `json:"direction"`
+	Role      string `json:"role"`
+}
+
+type queryPredicates struct {
+	equality   []string
+	ranges     []string
+	sort       []IndexKey
+	projection []string
+	excludesID bool
+}
+
+type recommendation struct {
+	suggestion IndexSuggestion
+	keys       []IndexKey
+}
+
+func (s *Service) recommendIndexes(ctx context.Context, dbName string, queries []mongodb.SlowQuery) ([]IndexSuggestion, int, []string) {
+	recommendations := make(map[string]*recommendation)
+	order := make([]string, 0)
+	existing := make(map[string][]mongodb.IndexInfo)
+	skipped := 0
+	var errs []string
+
+	for _, q := range queries {
+		if !needsIndex(q) {
+			continue
+		}
+
+		preds, ok := extractPredicates(profiledCommand(q))
+		if !ok {
+			continue
+		}
+
+		keys := buildESRKeys(preds)
+		if len(keys) == 0 {
+			continue
+		}
+
+		collection := extractCollection(q.Namespace)
+		indexes, loaded := existing[collection]
+		if !loaded {
+			var err error
+			indexes, err = s.indexes.GetIndexes(ctx, dbName, collection)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: list indexes: %v", collection, err))
+			}
+			existing[collection] = indexes
+		}
+		if name := matchingIndex(keys, indexes); name != "" {
+			skipped++
+			continue
+		}
+
+		id := q.Namespace + ":" + indexKeySpec(keys)
+		rec, ok := recommendations[id]
+		if !ok {
+			rec = &recommendation{
+				keys:       keys,
+				suggestion: newIndexSuggestion(q.Namespace, collection, keys, preds),
+			}
+			recommendations[id] = rec
+			order = append(order, id)
+		}
+
+		rec.suggestion.Occurrences++
+		rec.suggestion.TotalMillis += int64(q.MillisRuntime)
+		rec.suggestion.DocsExamined += q.DocsExamined
+		rec.suggestion.KeysExamined += q.KeysExamined
+		rec.suggestion.Returned += q.NReturned
+		if q.PlanSummary != "" && !strings.Contains(rec.suggestion.QueryPattern, q.PlanSummary) {
+			if rec.suggestion.QueryPattern != "" {
+				rec.suggestion.QueryPattern += ", "
+			}
+			rec.suggestion.QueryPattern += q.PlanSummary
+		}
+	}
+
+	suggestions := make([]IndexSuggestion, 0, len(order))
+	for _, id := range order {
+		suggestion := recommendations[id].suggestion
+		estimateBenefit(&suggestion)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].DocsExamined-suggestions[i].Returned > suggestions[j].DocsExamined-suggestions[j].Returned
+	})
+
+	return suggestions, skipped, errs
+}
+
+func needsIndex(q mongodb.SlowQuery) bool {
+	if q.DocsExamined < minDocsExaminedForIndex && !q.HasSortStage {
+		return false
+	}
+	if strings.Contains(q.PlanSummary, "COLLSCAN") || q.HasSortStage {
+		return true
+	}
+
+	returned := q.NReturned
+	if returned == 0 {
+		returned = 1
+	}
+	return float64(q.DocsExamined)/float64(returned) >= minExaminedPerReturned
+}
+
+func extractPredicates(cmd bson.Raw) (queryPredicates, bool) {
+	var preds queryPredicates
+	if len(cmd) == 0 {
+		return preds, false
+	}
+
+	var filter, sortSpec, projection bson.Raw
+	for _, field := range []string{"filter", "query", "q"} {
+		if v, err := cmd.LookupErr(field); err == nil && v.Type == bson.TypeEmbeddedDocument {
+			filter = v.Document()
+			break
+		}
+	}
+	if v, err := cmd.LookupErr("sort"); err == nil && v.Type == bson.TypeEmbeddedDocument {
+		sortSpec = v.Document()
+	}
+	for _, field := range []string{"projection", "fields"} {
+		if v, err := cmd.LookupErr(field); err == nil && v.Type == bson.TypeEmbeddedDocument {
+			projection = v.Document()
+			break
+		}
+	}
+
+	if v, err := cmd.LookupErr("pipeline"); err == nil && v.Type == bson.TypeArray {
+		filter, sortSpec, projection = pipelinePredicates(v.Array())
+	}
+
+	if filter != nil {
+		collectFilter(filter, &preds)
+	}
+	if sortSpec != nil {
+		preds.sort = sortKeys(sortSpec)
+	}
+	if projection != nil {
+		preds.projection, preds.excludesID = projectionFields(projection)
+	}
+
+	return preds, len(preds.equality)+len(preds.ranges)+len(preds.sort) > 0
+}
+
+func pipelinePredicates(pipeline bson.RawArray) (bson.Raw, bson.Raw, bson.Raw) {
+	var filter, sortSpec, projection bson.Raw
+
+	stages, err := pipeline.Values()
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	for i, stage := range stages {
+		if stage.Type != bson.TypeEmbeddedDocument {
+			break
+		}
+		doc := stage.Document()
+
+		if v, err := doc.LookupErr("$match"); err == nil && i == 0 && v.Type == bson.TypeEmbeddedDocument {
+			filter = v.Document()
+			continue
+		}
+		if v, err := doc.LookupErr("$sort"); err == nil && sortSpec == nil && v.Type == bson.TypeEmbeddedDocument {
+			sortSpec = v.Document()
+			continue
+		}
+		if v, err := doc.LookupErr("$project"); err == nil && projection == nil && v.Type == bson.TypeEmbeddedDocument {
+			projection = v.Document()
+		}
+		break
+	}
+
+	return filter, sortSpec, projection
+}
+
+func collectFilter(filter bson.Raw, preds *queryPredicates) {
+	elements, err := filter.Elements()
+	if err != nil {
+		return
+	}
+
+	for _, e := range elements {
+		key := e.Key()
+		value := e.Value()
+
+		if key == "$and" && value.Type == bson.TypeArray {
+			clauses, _ := value.Array().Values()
+			for _, clause := range clauses {
+				if clause.Type == bson.TypeEmbeddedDocument {
+					collectFilter(clause.Document(), preds)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+
+		switch classifyPredicate(value) {
+		case keyRoleEquality:
+			preds.equality = appendUnique(preds.equality, key)
+		case keyRoleRange:
+			preds.ranges = appendUnique(preds.ranges, key)
+		}
+	}
+}
+
+func classifyPredicate(value bson.RawValue) string {
+	if value.Type == bson.TypeRegex {
+		return keyRoleRange
+	}
+	if value.Type != bson.TypeEmbeddedDocument {
+		return keyRoleEquality
+	}
+
+	elements, err := value.Document().Elements()
+	if err != nil || len(elements) == 0 || !strings.HasPrefix(elements[0].Key(), "$") {
+		return keyRoleEquality
+	}
+
+	role := ""
+	for _, e := range elements {
+		op := e.Key()
+		if op == "$in" {
+			if arr, ok := e.Value().ArrayOK(); ok {
+				if values, err := arr.Values(); err == nil && len(values) > 200 {
+					role = keyRoleRange
+					continue
+				}
+			}
+		}
+		if equalityOperators[op] && role == "" {
+			role = keyRoleEquality
+		}
+		if rangeOperators[op] {
+			role = keyRoleRange
+		}
+	}
+	return role
+}
+
+func sortKeys(spec bson.Raw) []IndexKey {
+	elements, err := spec.Elements()
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]IndexKey, 0, len(elements))
+	for _, e := range elements {
+		direction, ok := e.Value().AsInt64OK()
+		if !ok {
+			continue
+		}
+		dir := 1
+		if direction < 0 {
+			dir = -1
+		}
+		keys = append(keys, IndexKey{Field: e.Key(), Direction: dir, Role: keyRoleSort})
+	}
+	return keys
+}
+
+func projectionFields(spec bson.Raw) ([]string, bool) {
+	elements, err := spec.Elements()
+	if err != nil {
+		return nil, false
+	}
+
+	var fields []string
+	excludesID := false
+	for _, e := range elements {
+		included := true
+		switch v := e.Value(); v.Type {
+		case bson.TypeBoolean:
+			included = v.Boolean()
+		case bson.TypeInt32, bson.TypeInt64, bson.TypeDouble:
+			n, _ := v.AsInt64OK()
+			included = n != 0
+		default:
+			return nil, false
+		}
+
+		if e.Key() == "_id" {
+			excludesID = !included
+			continue
+		}
+		if !included {
+			return nil, false
+		}
+		fields = append(fields, e.Key())
+	}
+	return fields, excludesID
+}
+
+func buildESRKeys(preds queryPredicates) []IndexKey {
+	used := make(map[string]bool)
+	keys := make([]IndexKey, 0, len(preds.equality)+len(preds.sort)+len(preds.ranges))
+
+	add := func(key IndexKey) {
+		if used[key.Field] || len(keys) >= maxIndexKeys {
+			return
+		}
+		used[key.Field] = true
+		keys = append(keys, key)
+	}
+
+	for _, field := range preds.equality {
+		add(IndexKey{Field: field, Direction: 1, Role: keyRoleEquality})
+	}
+	for _, key := range preds.sort {
+		add(key)
+	}
+	for _, field := range preds.ranges {
+		add(IndexKey{Field: field, Direction: 1, Role: keyRoleRange})
+	}
+
+	return keys
+}
+
+func matchingIndex(keys []IndexKey, indexes []mongodb.IndexInfo) string {
+	equality := make(map[string]bool)
+	for _, key := range keys {
+		if key.Role == keyRoleEquality {
+			equality[key.Field] = true
+		}
+	}
+
+	for _, idx := range indexes {
+		if len(idx.Keys) < len(keys) {
+			continue
+		}
+
+		matched := true
+		for i := range len(equality) {
+			if !equality[idx.Keys[i].Field] || idx.Keys[i].Direction == 0 {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		forward, reverse := true, true
+		for i := len(equality); i < len(keys); i++ {
+			key := keys[i]
+			if idx.Keys[i].Field != key.Field || idx.Keys[i].Direction == 0 {
+				forward, reverse = false, false
+				break
+			}
+			if key.Role != keyRoleSort {
+				continue
+			}
+			dir := idx.Keys[i].Direction
+			if dir != key.Direction {
+				forward = false
+			}
+			if dir != -key.Direction {
+				reverse = false
+			}
+		}
+
+		if forward || reverse {
+			return idx.Name
+		}
+	}
+	return ""
+}
+
+func newIndexSuggestion(namespace, collection string, keys []IndexKey, preds queryPredicates) IndexSuggestion {
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, key.Field)
+	}
+
+	suggestion := IndexSuggestion{
+		Collection:     namespace,
+		SuggestedIndex: fields,
+		IndexKeys:      keys,
+		EqualityFields: fieldsWithRole(keys, keyRoleEquality),
+		SortFields:     fieldsWithRole(keys, keyRoleSort),
+		RangeFields:    fieldsWithRole(keys, keyRoleRange),
+		Covered:        coversProjection(fields, preds),
+		CreateCommand:  fmt.Sprintf("db.getCollection(%q).createIndex(%s)", collection, indexKeySpec(keys)),
+	}
+
+	reason := "Compound index following equality-sort-range order"
+	if len(suggestion.SortFields) > 0 {
+		reason += " removes the in-memory sort"
+	}
+	if suggestion.Covered {
+		reason += " and covers the projection"
+	}
+	suggestion.Reason = reason
+
+	return suggestion
+}
+
+func estimateBenefit(s *IndexSuggestion) {
+	returned := s.Returned
+	if returned == 0 {
+		returned = 1
+	}
+	s.ExaminedPerReturned = float64(s.DocsExamined) / float64(returned)
+
+	if s.DocsExamined > 0 && s.DocsExamined > s.Returned {
+		s.EstimatedReduction = float64(s.DocsExamined-s.Returned) / float64(s.DocsExamined) * 100
+	}
+
+	switch {
+	case s.ExaminedPerReturned >= 1000 || s.DocsExamined >= 1000000:
+		s.Benefit = "high"
+	case s.ExaminedPerReturned >= 100 || len(s.SortFields) > 0:
+		s.Benefit = "medium"
+	default:
+		s.Benefit = "low"
+	}
+}
+
+func coversProjection(indexFields []string, preds queryPredicates) bool {
+	if len(preds.projection) == 0 || !preds.excludesID {
+		return false
+	}
+
+	inIndex := make(map[string]bool, len(indexFields))
+	for _, field := range indexFields {
+		inIndex[field] = true
+	}
+	for _, field := range preds.projection {
+		if !inIndex[field] {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldsWithRole(keys []IndexKey, role string) []string {
+	fields := make([]string, 0)
+	for _, key := range keys {
+		if key.Role == role {
+			fields = append(fields, key.Field)
+		}
+	}
+	return fields
+}
+
+func indexKeySpec(keys []IndexKey) string {
+	spec := bson.D{}
+	for _, key := range keys {
+		spec = append(spec, bson.E{Key: key.Field, Value: key.Direction})
+	}
+
+	out, err := bson.MarshalExtJSON(spec, false, false)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -8,8 +8,10 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/carterperez-dev/templates/go-backend/internal/kpi"
 	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
 )
 
@@ -19,35 +21,52 @@ type metricsRepository interface {
 	GetCurrentOps(ctx context.Context) ([]mongodb.Operation, error)
 	ListDatabases(ctx context.Context) ([]string, error)
 	GetCollectionCount(ctx context.Context, dbName string) (int, error)
-	GetTruePaidSubscribers(ctx context.Context, dbName string) (int64, error)
 	GetSlowQueries(ctx context.Context, dbName string, minMillis int, limit int) ([]mongodb.SlowQuery, error)
 	GetProfilingStatus(ctx context.Context, dbName string) (int, int, error)
 	SetProfilingLevel(ctx context.Context, dbName string, level int, slowMs int) error
+	GetTop(ctx context.Context) (map[string]mongodb.TopStats, error)
+}
+
+type indexLister interface {
+	GetIndexes(ctx context.Context, dbName, collName string) ([]mongodb.IndexInfo, error)
+}
+
+type kpiSource interface {
+	Latest(clusterName string) map[string]kpi.Value
 }
 
 type Service struct {
 	repo     metricsRepository
+	indexes  indexLister
+	kpis     kpiSource
 	database string
+	top      *topTracker
 }
 
-func NewService(repo metricsRepository, database string) *Service {
+func NewService(repo metricsRepository, indexes indexLister, kpis kpiSource, database string) *Service {
 	return &Service{
 		repo:     repo,
+		indexes:  indexes,
+		kpis:     kpis,
 		database: database,
+		top:      newTopTracker(),
 	}
 }
 
 type DashboardMetrics struct {
-	Timestamp       time.Time          `json:"timestamp"`
-	Server          ServerMetrics      `json:"server"`
-	Database        DatabaseMetrics    `json:"database"`
-	Connections     ConnectionStats    `json:"connections"`
-	Operations      OpCounters         `json:"operations"`
-	Memory          MemoryStats        `json:"memory"`
-	Network         NetworkStats       `json:"network"`
-	ActiveOps       int                `json:"active_ops"`
-	CurrentOps      []CurrentOperation `json:"current_ops"`
-	PaidSubscribers int64              `json:"paid_subscribers"`
+	Timestamp   time.Time            `json:"timestamp"`
+	Server      ServerMetrics        `json:"server"`
+	Database    DatabaseMetrics      `json:"database"`
+	Connections ConnectionStats      `json:"connections"`
+	Operations  OpCounters           `json:"operations"`
+	Memory      MemoryStats          `json:"memory"`
+	Network     NetworkStats         `json:"network"`
+	Storage     StorageStats         `json:"storage"`
+	GlobalLock  GlobalLockStats      `json:"global_lock"`
+	Documents   DocumentStats        `json:"documents"`
+	ActiveOps   int                  `json:"active_ops"`
+	CurrentOps  []CurrentOperation   `json:"current_ops"`
+	KPIs        map[string]kpi.Value `json:"kpis"`
 }
 
 type CurrentOperation struct {
@@ -104,13 +123,56 @@ type NetworkStats struct {
 	NumRequests int64   `json:"num_requests"`
 }
 
-func (s *Service) GetDashboardMetrics(ctx context.Context) (*DashboardMetrics, error) {
+type StorageStats struct {
+	Engine  string       `json:"engine"`
+	Cache   *CacheStats  `json:"cache,omitempty"`
+	Tickets *TicketStats `json:"tickets,omitempty"`
+}
+
+type CacheStats struct {
+	BytesInCacheMB   float64 `json:"bytes_in_cache_mb"`
+	MaxBytesMB       float64 `json:"max_bytes_mb"`
+	DirtyBytesMB     float64 `json:"dirty_bytes_mb"`
+	UsedPercent      float64 `json:"used_percent"`
+	DirtyPercent     float64 `json:"dirty_percent"`
+	PagesReadInto    int64   `json:"pages_read_into"`
+	PagesWrittenFrom int64   `json:"pages_written_from"`
+}
+
+type TicketStats struct {
+	ReadOut        int64 `json:"read_out"`
+	ReadAvailable  int64 `json:"read_available"`
+	ReadTotal      int64 `json:"read_total"`
+	WriteOut       int64 `json:"write_out"`
+	WriteAvailable int64 `json:"write_available"`
+	WriteTotal     int64 `json:"write_total"`
+}
+
+type GlobalLockStats struct {
+	QueueTotal    int64 `json:"queue_total"`
+	QueueReaders  int64 `json:"queue_readers"`
+	QueueWriters  int64 `json:"queue_writers"`
+	ActiveTotal   int64 `json:"active_total"`
+	ActiveReaders int64 `json:"active_readers"`
+	ActiveWriters int64 `json:"active_writers"`
+}
+
+type DocumentStats struct {
+	Inserted int64 `json:"inserted"`
+	Returned int64 `json:"returned"`
+	Updated  int64 `json:"updated"`
+	Deleted  int64 `json:"deleted"`
+}
+
+func (s *Service) GetDashboardMetrics(ctx context.Context, dbName string) (*DashboardMetrics, error) {
+	dbName = s.resolveDatabase(ctx, dbName)
+
 	serverStatus, err := s.repo.GetServerStatus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get server status: %w", err)
 	}
 
-	dbStats, err := s.repo.GetDatabaseStats(ctx, s.database)
+	dbStats, err := s.repo.GetDatabaseStats(ctx, dbName)
 	if err != nil {
 		return nil, fmt.Errorf("get database stats: %w", err)
 	}
@@ -125,11 +187,6 @@ func (s *Service) GetDashboardMetrics(ctx context.Context) (*DashboardMetrics, e
 		return nil, fmt.Errorf("get current ops: %w", err)
 	}
 
-	paidSubs, err := s.repo.GetTruePaidSubscribers(ctx, s.database)
-	if err != nil {
-		return nil, fmt.Errorf("get paid subscribers: %w", err)
-	}
-
 	totalOps := serverStatus.Opcounters.Insert +
 		serverStatus.Opcounters.Query +
 		serverStatus.Opcounters.Update +
@@ -159,7 +216,7 @@ func (s *Service) GetDashboardMetrics(ctx context.Context) (*DashboardMetrics, e
 			UptimeSec: serverStatus.Uptime,
 		},
 		Database: DatabaseMetrics{
-			Name:           s.database,
+			Name:           dbName,
 			Collections:    dbStats.Collections,
 			Documents:      dbStats.Objects,
 			DataSizeMB:     bytesToMB(dbStats.DataSize),
@@ -191,12 +248,142 @@ func (s *Service) GetDashboardMetrics(ctx context.Context) (*DashboardMetrics, e
 			BytesOutMB:  bytesToMB(float64(serverStatus.Network.BytesOut)),
 			NumRequests: serverStatus.Network.NumRequests,
 		},
-		ActiveOps:       len(activeOps),
-		CurrentOps:      currentOps,
-		PaidSubscribers: paidSubs,
+		Storage: buildStorageStats(serverStatus),
+		GlobalLock: GlobalLockStats{
+			QueueTotal:    serverStatus.GlobalLock.CurrentQueue.Total,
+			QueueReaders:  serverStatus.GlobalLock.CurrentQueue.Readers,
+			QueueWriters:  serverStatus.GlobalLock.CurrentQueue.Writers,
+			ActiveTotal:   serverStatus.GlobalLock.ActiveClients.Total,
+			ActiveReaders: serverStatus.GlobalLock.ActiveClients.Readers,
+			ActiveWriters: serverStatus.GlobalLock.ActiveClients.Writers,
+		},
+		Documents: DocumentStats{
+			Inserted: serverStatus.Metrics.Document.Inserted,
+			Returned: serverStatus.Metrics.Document.Returned,
+			Updated:  serverStatus.Metrics.Document.Updated,
+			Deleted:  serverStatus.Metrics.Document.Deleted,
+		},
+		ActiveOps:  len(activeOps),
+		CurrentOps: currentOps,
+		KPIs:       s.kpis.Latest(mongodb.ClusterName(ctx)),
 	}, nil
 }
 
+func buildStorageStats(status *mongodb.ServerStatus) StorageStats {
+	stats := StorageStats{
+		Engine: status.StorageEngine.Name,
+	}
+
+	tickets := status.Queues.Execution
+
+	if wt := status.WiredTiger; wt != nil {
+		cache := &CacheStats{
+			BytesInCacheMB:   bytesToMB(float64(wt.Cache.BytesInCache)),
+			MaxBytesMB:       bytesToMB(float64(wt.Cache.MaxBytesConfigured)),
+			DirtyBytesMB:     bytesToMB(float64(wt.Cache.TrackedDirtyBytes)),
+			PagesReadInto:    wt.Cache.PagesReadInto,
+			PagesWrittenFrom: wt.Cache.PagesWrittenFrom,
+		}
+		if wt.Cache.MaxBytesConfigured > 0 {
+			maxBytes := float64(wt.Cache.MaxBytesConfigured)
+			cache.UsedPercent = float64(wt.Cache.BytesInCache) / maxBytes * 100
+			cache.DirtyPercent = float64(wt.Cache.TrackedDirtyBytes) / maxBytes * 100
+		}
+		stats.Cache = cache
+
+		if tickets.Read.TotalTickets == 0 && tickets.Write.TotalTickets == 0 {
+			tickets = wt.ConcurrentTransactions
+		}
+	}
+
+	if tickets.Read.TotalTickets > 0 || tickets.Write.TotalTickets > 0 {
+		stats.Tickets = &TicketStats{
+			ReadOut:        tickets.Read.Out,
+			ReadAvailable:  tickets.Read.Available,
+			ReadTotal:      tickets.Read.TotalTickets,
+			WriteOut:       tickets.Write.Out,
+			WriteAvailable: tickets.Write.Available,
+			WriteTotal:     tickets.Write.TotalTickets,
+		}
+	}
+
+	return stats
+}
+
+type DatabaseSummary struct {
+	Name          string  `json:"name"`
+	Collections   int     `json:"collections"`
+	Views         int     `json:"views"`
+	Objects       int64   `json:"objects"`
+	DataSizeMB    float64 `json:"data_size_mb"`
+	StorageSizeMB float64 `json:"storage_size_mb"`
+	Indexes       int     `json:"indexes"`
+	IndexSizeMB   float64 `json:"index_size_mb"`
+	TotalSizeMB   float64 `json:"total_size_mb"`
+}
+
+type DatabasesOverview struct {
+	Count           int               `json:"count"`
+	TotalSizeMB     float64           `json:"total_size_mb"`
+	TotalObjects    int64             `json:"total_objects"`
+	DefaultDatabase string            `json:"default_database"`
+	Databases       []DatabaseSummary `json:"databases"`
+	Errors          []string          `json:"errors,omitempty"`
+}
+
+func (s *Service) GetDatabasesOverview(ctx context.Context) (*DatabasesOverview, error) {
+	names, err := s.repo.ListDatabases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list databases: %w", err)
+	}
+
+	overview := &DatabasesOverview{
+		DefaultDatabase: s.resolveDatabase(ctx, ""),
+		Databases:       make([]DatabaseSummary, 0, len(names)),
+	}
+
+	for _, name := range names {
+		stats, err := s.repo.GetDatabaseStats(ctx, name)
+		if err != nil {
+			overview.Errors = append(overview.Errors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+
+		summary := DatabaseSummary{
+			Name:          name,
+			Collections:   stats.Collections,
+			Views:         stats.Views,
+			Objects:       stats.Objects,
+			DataSizeMB:    bytesToMB(stats.DataSize),
+			StorageSizeMB: bytesToMB(stats.StorageSize),
+			Indexes:       stats.Indexes,
+			IndexSizeMB:   bytesToMB(stats.IndexSize),
+			TotalSizeMB:   bytesToMB(stats.StorageSize + stats.IndexSize),
+		}
+
+		overview.TotalSizeMB += summary.TotalSizeMB
+		overview.TotalObjects += summary.Objects
+		overview.Databases = append(overview.Databases, summary)
+	}
+
+	sort.Slice(overview.Databases, func(i, j int) bool {
+		return overview.Databases[i].TotalSizeMB > overview.Databases[j].TotalSizeMB
+	})
+	overview.Count = len(overview.Databases)
+
+	return overview, nil
+}
+
+func (s *Service) resolveDatabase(ctx context.Context, dbName string) string {
+	if dbName != "" {
+		return dbName
+	}
+	if cluster, ok := mongodb.ClientFromContext(ctx); ok && cluster.DefaultDatabase() != "" {
+		return cluster.DefaultDatabase()
+	}
+	return s.database
+}
+
 func bytesToMB(bytes float64) float64 {
 	return bytes / (1024 * 1024)
 }
@@ -231,11 +418,24 @@ type ProfilingStatus struct {
 }
 
 type IndexSuggestion struct {
-	Collection     string   `json:"collection"`
-	SuggestedIndex []string `json:"suggested_index"`
-	Reason         string   `json:"reason"`
-	QueryPattern   string   `json:"query_pattern"`
-	Occurrences    int      `json:"occurrences"`
+	Collection          string     `json:"collection"`
+	SuggestedIndex      []string   `json:"suggested_index"`
+	IndexKeys           []IndexKey `json:"index_keys"`
+	EqualityFields      []string   `json:"equality_fields"`
+	SortFields          []string   `json:"sort_fields"`
+	RangeFields         []string   `json:"range_fields"`
+	Covered             bool       `json:"covered"`
+	CreateCommand       string     `json:"create_command"`
+	Reason              string     `json:"reason"`
+	QueryPattern        string     `json:"query_pattern"`
+	Occurrences         int        `json:"occurrences"`
+	TotalMillis         int64      `json:"total_millis"`
+	DocsExamined        int64      `json:"docs_examined"`
+	KeysExamined        int64      `json:"keys_examined"`
+	Returned            int64      `json:"returned"`
+	ExaminedPerReturned float64    `json:"examined_per_returned"`
+	EstimatedReduction  float64    `json:"estimated_reduction_pct"`
+	Benefit             string     `json:"benefit"`
 }
 
 type SlowQueryAnalysis struct {
@@ -243,8 +443,11 @@ type SlowQueryAnalysis struct {
 	TotalQueries     int               `json:"total_queries"`
 	AnalyzedQueries  int               `json:"analyzed_queries"`
 	Suggestions      []IndexSuggestion `json:"suggestions"`
+	SkippedExisting  int               `json:"skipped_existing"`
 	TopCollections   []CollectionStats `json:"top_collections"`
 	TopOperations    []OperationStats  `json:"top_operations"`
+	TopShapes        []QueryShape      `json:"top_shapes"`
+	Errors           []string          `json:"errors,omitempty"`
 }
 
 type CollectionStats struct {
@@ -260,19 +463,21 @@ type OperationStats struct {
 	AvgMillis float64 `json:"avg_millis"`
 }
 
-func (s *Service) GetSlowQueries(ctx context.Context, minMillis, limit int) (*SlowQueryReport, error) {
-	level, slowMs, err := s.repo.GetProfilingStatus(ctx, s.database)
+func (s *Service) GetSlowQueries(ctx context.Context, dbName string, minMillis, limit int) (*SlowQueryReport, error) {
+	dbName = s.resolveDatabase(ctx, dbName)
+
+	level, slowMs, err := s.repo.GetProfilingStatus(ctx, dbName)
 	if err != nil {
 		return nil, fmt.Errorf("get profiling status: %w", err)
 	}
 
-	queries, err := s.repo.GetSlowQueries(ctx, s.database, minMillis, limit)
+	queries, err := s.repo.GetSlowQueries(ctx, dbName, minMillis, limit)
 	if err != nil {
 		return nil, fmt.Errorf("get slow queries: %w", err)
 	}
 
 	return &SlowQueryReport{
-		Database:        s.database,
+		Database:        dbName,
 		ProfilingLevel:  level,
 		SlowMsThreshold: slowMs,
 		QueryCount:      len(queries),
@@ -280,36 +485,39 @@ func (s *Service) GetSlowQueries(ctx context.Context, minMillis, limit int) (*Sl
 	}, nil
 }
 
-func (s *Service) GetProfilingStatus(ctx context.Context) (*ProfilingStatus, error) {
-	level, slowMs, err := s.repo.GetProfilingStatus(ctx, s.database)
+func (s *Service) GetProfilingStatus(ctx context.Context, dbName string) (*ProfilingStatus, error) {
+	dbName = s.resolveDatabase(ctx, dbName)
+
+	level, slowMs, err := s.repo.GetProfilingStatus(ctx, dbName)
 	if err != nil {
 		return nil, fmt.Errorf("get profiling status: %w", err)
 	}
 
 	return &ProfilingStatus{
-		Database: s.database,
+		Database: dbName,
 		Level:    level,
 		SlowMs:   slowMs,
 	}, nil
 }
 
-func (s *Service) SetProfilingLevel(ctx context.Context, level, slowMs int) error {
+func (s *Service) SetProfilingLevel(ctx context.Context, dbName string, level, slowMs int) error {
 	if level < 0 || level > 2 {
 		return fmt.Errorf("invalid profiling level: must be 0, 1, or 2")
 	}
 
-	return s.repo.SetProfilingLevel(ctx, s.database, level, slowMs)
+	return s.repo.SetProfilingLevel(ctx, s.resolveDatabase(ctx, dbName), level, slowMs)
 }
 
-func (s *Service) AnalyzeSlowQueries(ctx context.Context, minMillis, limit int) (*SlowQueryAnalysis, error) {
-	queries, err := s.repo.GetSlowQueries(ctx, s.database, minMillis, limit)
+func (s *Service) AnalyzeSlowQueries(ctx context.Context, dbName string, minMillis, limit int) (*SlowQueryAnalysis, error) {
+	dbName = s.resolveDatabase(ctx, dbName)
+
+	queries, err := s.repo.GetSlowQueries(ctx, dbName, minMillis, limit)
 	if err != nil {
 		return nil, fmt.Errorf("get slow queries: %w", err)
 	}
 
 	collectionMap := make(map[string]*collectionAggregator)
 	operationMap := make(map[string]*operationAggregator)
-	suggestionMap := make(map[string]*IndexSuggestion)
 
 	for _, q := range queries {
 		if agg, ok := collectionMap[q.Namespace]; ok {
@@ -337,36 +545,6 @@ func (s *Service) AnalyzeSlowQueries(ctx context.Context, minMillis, limit int)
 				totalMillis: q.MillisRuntime,
 			}
 		}
-
-		if q.PlanSummary == "COLLSCAN" && q.DocsExamined > 100 {
-			key := q.Namespace + ":COLLSCAN"
-			if sug, ok := suggestionMap[key]; ok {
-				sug.Occurrences++
-			} else {
-				suggestionMap[key] = &IndexSuggestion{
-					Collection:     q.Namespace,
-					SuggestedIndex: []string{"_id"},
-					Reason:         "Collection scan detected with high document examination",
-					QueryPattern:   "COLLSCAN",
-					Occurrences:    1,
-				}
-			}
-		}
-
-		if q.KeysExamined > 0 && q.DocsExamined > q.KeysExamined*10 {
-			key := q.Namespace + ":INEFFICIENT_INDEX"
-			if sug, ok := suggestionMap[key]; ok {
-				sug.Occurrences++
-			} else {
-				suggestionMap[key] = &IndexSuggestion{
-					Collection:     q.Namespace,
-					SuggestedIndex: []string{"examine query filter fields"},
-					Reason:         fmt.Sprintf("Inefficient index usage: %d docs examined vs %d keys", q.DocsExamined, q.KeysExamined),
-					QueryPattern:   q.PlanSummary,
-					Occurrences:    1,
-				}
-			}
-		}
 	}
 
 	var topCollections []CollectionStats
@@ -388,18 +566,24 @@ func (s *Service) AnalyzeSlowQueries(ctx context.Context, minMillis, limit int)
 		})
 	}
 
-	var suggestions []IndexSuggestion
-	for _, sug := range suggestionMap {
-		suggestions = append(suggestions, *sug)
+	suggestions, skipped, errs := s.recommendIndexes(ctx, dbName, queries)
+
+	topShapes := groupQueryShapes(queries)
+	sortQueryShapes(topShapes, "total")
+	if len(topShapes) > 10 {
+		topShapes = topShapes[:10]
 	}
 
 	return &SlowQueryAnalysis{
-		Database:        s.database,
+		Database:        dbName,
 		TotalQueries:    len(queries),
 		AnalyzedQueries: len(queries),
 		Suggestions:     suggestions,
+		SkippedExisting: skipped,
 		TopCollections:  topCollections,
 		TopOperations:   topOperations,
+		TopShapes:       topShapes,
+		Errors:          errs,
 	}, nil
 }
 
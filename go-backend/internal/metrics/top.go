@@ -0,0 +1,223 @@
+/*
+AngelaMos | 2026
+top.go
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	topBaselineDelay = time.Second
+	topMaxReportAge  = 5 * time.Second
+)
+
+type TopReport struct {
+	Cluster     string          `json:"cluster"`
+	Timestamp   time.Time       `json:"timestamp"`
+	IntervalMs  int64           `json:"interval_ms"`
+	SortBy      string          `json:"sort_by"`
+	Collections []TopCollection `json:"collections"`
+}
+
+type TopCollection struct {
+	Namespace         string  `json:"namespace"`
+	Database          string  `json:"database"`
+	Collection        string  `json:"collection"`
+	TotalTimeMs       float64 `json:"total_time_ms"`
+	TotalCount        int64   `json:"total_count"`
+	ReadTimeMs        float64 `json:"read_time_ms"`
+	ReadCount         int64   `json:"read_count"`
+	WriteTimeMs       float64 `json:"write_time_ms"`
+	WriteCount        int64   `json:"write_count"`
+	AvgReadLatencyMs  float64 `json:"avg_read_latency_ms"`
+	AvgWriteLatencyMs float64 `json:"avg_write_latency_ms"`
+}
+
+type topTracker struct {
+	mu     sync.Mutex
+	states map[string]*topState
+}
+
+type topState struct {
+	prev       map[string]mongodb.TopStats
+	prevTime   time.Time
+	latest     []TopCollection
+	latestTime time.Time
+	intervalMs int64
+}
+
+func newTopTracker() *topTracker {
+	return &topTracker{states: make(map[string]*topState)}
+}
+
+func (t *topTracker) record(cluster string, sample map[string]mongodb.TopStats, at time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.states[cluster]
+	if !ok {
+		t.states[cluster] = &topState{prev: sample, prevTime: at}
+		return false
+	}
+
+	collections := state.diff(sample)
+
+	state.intervalMs = at.Sub(state.prevTime).Milliseconds()
+	state.prev = sample
+	state.prevTime = at
+	state.latest = collections
+	state.latestTime = at
+	return true
+}
+
+func (st *topState) diff(sample map[string]mongodb.TopStats) []TopCollection {
+
+	collections := make([]TopCollection, 0, len(sample))
+	for ns, cur := range sample {
+		prev := st.prev[ns]
+
+		total := counterDelta(cur.Total, prev.Total)
+		read := counterDelta(cur.ReadLock, prev.ReadLock)
+		write := counterDelta(cur.WriteLock, prev.WriteLock)
+
+		if total.Count == 0 && total.Time == 0 {
+			continue
+		}
+
+		dbName, collName := mongodb.SplitNamespace(ns)
+		entry := TopCollection{
+			Namespace:   ns,
+			Database:    dbName,
+			Collection:  collName,
+			TotalTimeMs: microsToMillis(total.Time),
+			TotalCount:  total.Count,
+			ReadTimeMs:  microsToMillis(read.Time),
+			ReadCount:   read.Count,
+			WriteTimeMs: microsToMillis(write.Time),
+			WriteCount:  write.Count,
+		}
+		if read.Count > 0 {
+			entry.AvgReadLatencyMs = entry.ReadTimeMs / float64(read.Count)
+		}
+		if write.Count > 0 {
+			entry.AvgWriteLatencyMs = entry.WriteTimeMs / float64(write.Count)
+		}
+
+		collections = append(collections, entry)
+	}
+
+	return collections
+}
+
+func (t *topTracker) snapshot(cluster string, maxAge time.Duration) ([]TopCollection, time.Time, int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.states[cluster]
+	if !ok || state.latestTime.IsZero() || time.Since(state.latestTime) > maxAge {
+		return nil, time.Time{}, 0, false
+	}
+
+	collections := make([]TopCollection, len(state.latest))
+	copy(collections, state.latest)
+	return collections, state.latestTime, state.intervalMs, true
+}
+
+func (s *Service) SampleTop(ctx context.Context) (*TopReport, error) {
+	sample, err := s.repo.GetTop(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get top: %w", err)
+	}
+
+	cluster := clusterName(ctx)
+
+	if !s.top.record(cluster, sample, time.Now()) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(topBaselineDelay):
+		}
+
+		sample, err = s.repo.GetTop(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get top: %w", err)
+		}
+		s.top.record(cluster, sample, time.Now())
+	}
+
+	return s.GetTop(ctx, "total", 0)
+}
+
+func (s *Service) GetTop(ctx context.Context, sortBy string, limit int) (*TopReport, error) {
+	cluster := clusterName(ctx)
+
+	collections, at, intervalMs, ok := s.top.snapshot(cluster, topMaxReportAge)
+	if !ok {
+		if _, err := s.SampleTop(ctx); err != nil {
+			return nil, err
+		}
+		collections, at, intervalMs, _ = s.top.snapshot(cluster, topMaxReportAge)
+	}
+
+	switch sortBy {
+	case "read":
+		sort.Slice(collections, func(i, j int) bool {
+			return collections[i].ReadTimeMs > collections[j].ReadTimeMs
+		})
+	case "write":
+		sort.Slice(collections, func(i, j int) bool {
+			return collections[i].WriteTimeMs > collections[j].WriteTimeMs
+		})
+	case "count":
+		sort.Slice(collections, func(i, j int) bool {
+			return collections[i].TotalCount > collections[j].TotalCount
+		})
+	default:
+		sortBy = "total"
+		sort.Slice(collections, func(i, j int) bool {
+			return collections[i].TotalTimeMs > collections[j].TotalTimeMs
+		})
+	}
+
+	if limit > 0 && len(collections) > limit {
+		collections = collections[:limit]
+	}
+
+	return &TopReport{
+		Cluster:     cluster,
+		Timestamp:   at,
+		IntervalMs:  intervalMs,
+		SortBy:      sortBy,
+		Collections: collections,
+	}, nil
+}
+
+func counterDelta(cur, prev mongodb.TopCounter) mongodb.TopCounter {
+	if cur.Time < prev.Time || cur.Count < prev.Count {
+		return cur
+	}
+	return mongodb.TopCounter{
+		Time:  cur.Time - prev.Time,
+		Count: cur.Count - prev.Count,
+	}
+}
+
+func clusterName(ctx context.Context) string {
+	if cluster, ok := mongodb.ClientFromContext(ctx); ok {
+		return cluster.Name()
+	}
+	return ""
+}
+
+func microsToMillis(micros int64) float64 {
+	return float64(micros) / 1000.0
+}
@@ -0,0 +1,91 @@
+/*
+AngelaMos | 2026
+cluster.go
+*/
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const ClusterHeader = "X-Cluster"
+
+const clusterPathPrefix = "/api/clusters/"
+
+var clusterScopedResources = map[string]bool{
+	"metrics":       true,
+	"kpis":          true,
+	"collections":   true,
+	"backups":       true,
+	"operations":    true,
+	"profiler":      true,
+	"indexes":       true,
+	"exports":       true,
+	"audit":         true,
+	"saved-queries": true,
+	"schema-drift":  true,
+}
+
+type ClusterResolver interface {
+	Get(name string) (*mongodb.Client, error)
+}
+
+func ClusterSelector(resolver ClusterResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Header.Get(ClusterHeader)
+
+			rctx := chi.RouteContext(r.Context())
+			path := r.URL.Path
+			if rctx != nil && rctx.RoutePath != "" {
+				path = rctx.RoutePath
+			}
+
+			if pathCluster, rewritten, ok := splitClusterPath(path); ok {
+				name = pathCluster
+				r.URL.Path = rewritten
+				r.URL.RawPath = ""
+				if rctx != nil {
+					rctx.RoutePath = rewritten
+				}
+			}
+
+			if name == "" {
+				name = r.URL.Query().Get("cluster")
+			}
+
+			client, err := resolver.Get(name)
+			if err != nil {
+				core.NotFound(w, "cluster")
+				return
+			}
+
+			ctx := mongodb.WithClient(r.Context(), client)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func splitClusterPath(path string) (string, string, bool) {
+	if !strings.HasPrefix(path, clusterPathPrefix) {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(path, clusterPathPrefix), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || !clusterScopedResources[parts[1]] {
+		return "", "", false
+	}
+
+	rewritten := "/api/" + parts[1]
+	if len(parts) == 3 {
+		rewritten += "/" + parts[2]
+	}
+	return parts[0], rewritten, true
+}
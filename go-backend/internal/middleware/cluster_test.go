@@ -0,0 +1,153 @@
+/*
+AngelaMos | 2026
+cluster_test.go
+*/
+
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/middleware"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/server"
+)
+
+type recordingResolver struct {
+	names []string
+}
+
+func (r *recordingResolver) Get(name string) (*mongodb.Client, error) {
+	r.names = append(r.names, name)
+	if name != "" && name != "prod" {
+		return nil, mongodb.ErrClusterNotFound
+	}
+	return &mongodb.Client{}, nil
+}
+
+func TestClusterSelectorRoutesClusterPaths(t *testing.T) {
+	resolver := &recordingResolver{}
+
+	srv := server.New(server.Config{
+		ServerConfig: config.ServerConfig{},
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	router := srv.Router()
+	router.Use(middleware.ClusterSelector(resolver))
+
+	routed := func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := mongodb.ClientFromContext(r.Context()); !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, r.URL.Path)
+	}
+	router.Route("/api/collections", func(r chi.Router) {
+		r.Get("/{name}", routed)
+	})
+	router.Get("/api/kpis", routed)
+	router.Get("/api/audit", routed)
+	router.Route("/api/schema-drift", func(r chi.Router) {
+		r.Get("/snapshots", routed)
+	})
+	router.Route("/api/saved-queries", func(r chi.Router) {
+		r.Get("/", routed)
+		r.Post("/{id}/run", routed)
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantCluster string
+		wantPath    string
+	}{
+		{
+			name:        "cluster scoped collection",
+			path:        "/api/clusters/prod/collections/x",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/collections/x",
+		},
+		{
+			name:        "cluster scoped kpis",
+			path:        "/api/clusters/prod/kpis",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/kpis",
+		},
+		{
+			name:        "cluster scoped audit",
+			path:        "/api/clusters/prod/audit",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/audit",
+		},
+		{
+			name:        "cluster scoped schema drift",
+			path:        "/api/clusters/prod/schema-drift/snapshots/",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/schema-drift/snapshots",
+		},
+		{
+			name:        "cluster scoped saved queries",
+			path:        "/api/clusters/prod/saved-queries",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/saved-queries",
+		},
+		{
+			name:        "cluster scoped saved query run",
+			method:      http.MethodPost,
+			path:        "/api/clusters/prod/saved-queries/abc/run",
+			wantStatus:  http.StatusOK,
+			wantCluster: "prod",
+			wantPath:    "/api/saved-queries/abc/run",
+		},
+		{
+			name:        "unscoped path uses the default cluster",
+			path:        "/api/collections/x",
+			wantStatus:  http.StatusOK,
+			wantCluster: "",
+			wantPath:    "/api/collections/x",
+		},
+		{
+			name:        "unknown cluster",
+			path:        "/api/clusters/staging/collections/x",
+			wantStatus:  http.StatusNotFound,
+			wantCluster: "staging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver.names = nil
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d", method, tt.path, rec.Code, tt.wantStatus)
+			}
+			if len(resolver.names) != 1 || resolver.names[0] != tt.wantCluster {
+				t.Fatalf("%s %s resolved clusters %q, want %q", method, tt.path, resolver.names, tt.wantCluster)
+			}
+			if tt.wantPath != "" && rec.Body.String() != tt.wantPath {
+				t.Fatalf("%s %s routed to %q, want %q", method, tt.path, rec.Body.String(), tt.wantPath)
+			}
+		})
+	}
+}
@@ -19,8 +19,12 @@ import (
 type Client struct {
 	client   *mongo.Client
 	database string
+	name     string
+	uri      string
 }
 
+type clientKey struct{}
+
 func NewClient(ctx context.Context, cfg config.MongoConfig) (*Client, error) {
 	clientOpts := options.Client().
 		ApplyURI(cfg.URI).
@@ -40,12 +44,15 @@ func NewClient(ctx context.Context, cfg config.MongoConfig) (*Client, error) {
 	defer cancel()
 
 	if err := client.Ping(pingCtx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
 		return nil, fmt.Errorf("mongo ping: %w", err)
 	}
 
 	return &Client{
 		client:   client,
 		database: cfg.Database,
+		name:     cfg.Name,
+		uri:      cfg.URI,
 	}, nil
 }
 
@@ -67,3 +74,38 @@ func (c *Client) Database(name ...string) *mongo.Database {
 func (c *Client) Client() *mongo.Client {
 	return c.client
 }
+
+func (c *Client) Name() string {
+	return c.name
+}
+
+func (c *Client) URI() string {
+	return c.uri
+}
+
+func (c *Client) DefaultDatabase() string {
+	return c.database
+}
+
+func (c *Client) forContext(ctx context.Context) *Client {
+	if selected, ok := ClientFromContext(ctx); ok {
+		return selected
+	}
+	return c
+}
+
+func WithClient(ctx context.Context, c *Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+func ClientFromContext(ctx context.Context) (*Client, bool) {
+	c, ok := ctx.Value(clientKey{}).(*Client)
+	return c, ok && c != nil
+}
+
+func ClusterName(ctx context.Context) string {
+	if c, ok := ClientFromContext(ctx); ok {
+		return c.Name()
+	}
+	return ""
+}
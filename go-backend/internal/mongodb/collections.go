@@ -7,8 +7,11 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -33,23 +36,46 @@ type CollectionInfo struct {
 }
 
 type CollectionStats struct {
-	Name          string  `json:"name"`
-	DocumentCount int64   `json:"document_count"`
-	SizeBytes     int64   `json:"size_bytes"`
-	AvgDocSize    int64   `json:"avg_doc_size"`
-	StorageSize   int64   `json:"storage_size"`
-	IndexCount    int     `json:"index_count"`
-	TotalIndexSize int64  `json:"total_index_size"`
-	Capped        bool    `json:"capped"`
+	Name            string               `json:"name"`
+	DocumentCount   int64                `json:"document_count"`
+	SizeBytes       int64                `json:"size_bytes"`
+	AvgDocSize      int64                `json:"avg_doc_size"`
+	StorageSize     int64                `json:"storage_size"`
+	FreeStorageSize int64                `json:"free_storage_size"`
+	IndexCount      int                  `json:"index_count"`
+	TotalIndexSize  int64                `json:"total_index_size"`
+	TotalSize       int64                `json:"total_size"`
+	IndexSizes      map[string]int64     `json:"index_sizes"`
+	Capped          bool                 `json:"capped"`
+	Latency         *LatencyStats        `json:"latency,omitempty"`
+	CollectionScans *CollectionScanStats `json:"collection_scans,omitempty"`
 }
 
 type FieldSchema struct {
-	Name       string   `json:"name"`
-	Types      []string `json:"types"`
-	Coverage   float64  `json:"coverage"`
-	Count      int64    `json:"count"`
-	TotalDocs  int64    `json:"total_docs"`
-	SampleValues []any  `json:"sample_values,omitempty"`
+	Name         string            `json:"name"`
+	Types        []string          `json:"types"`
+	TypeCounts   []TypeCount       `json:"type_counts"`
+	Coverage     float64           `json:"coverage"`
+	Count        int64             `json:"count"`
+	Occurrences  int64             `json:"occurrences"`
+	TotalDocs    int64             `json:"total_docs"`
+	StringLength *LengthRange      `json:"string_length,omitempty"`
+	ArrayLength  *LengthRange      `json:"array_length,omitempty"`
+	SampleValues []json.RawMessage `json:"sample_values,omitempty"`
+	RawSamples   []bson.RawValue   `json:"-"`
+	Distinct     []bson.RawValue   `json:"-"`
+}
+
+type TypeCount struct {
+	Type       string  `json:"type"`
+	Count      int64   `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+type LengthRange struct {
+	Min int64   `json:"min"`
+	Max int64   `json:"max"`
+	Avg float64 `json:"avg"`
 }
 
 type SchemaAnalysis struct {
@@ -59,13 +85,41 @@ type SchemaAnalysis struct {
 	Fields         []FieldSchema `json:"fields"`
 }
 
+func (a *SchemaAnalysis) Encode(format string) {
+	for i := range a.Fields {
+		field := &a.Fields[i]
+		field.SampleValues = make([]json.RawMessage, 0, len(field.RawSamples))
+		for _, v := range field.RawSamples {
+			field.SampleValues = append(field.SampleValues, EncodeExtJSONValue(v, format))
+		}
+	}
+}
+
 type IndexInfo struct {
-	Name       string         `json:"name"`
-	Keys       map[string]int `json:"keys"`
-	Unique     bool           `json:"unique"`
-	Sparse     bool           `json:"sparse"`
-	Background bool           `json:"background"`
-	SizeBytes  int64          `json:"size_bytes"`
+	Name       string          `json:"name"`
+	Keys       []IndexKeyField `json:"keys"`
+	Unique     bool            `json:"unique"`
+	Sparse     bool            `json:"sparse"`
+	Background bool            `json:"background"`
+	SizeBytes  int64           `json:"size_bytes"`
+	Usage      *IndexUsage     `json:"usage,omitempty"`
+	Spec       bson.Raw        `json:"-"`
+
+	Hidden                  bool            `json:"hidden"`
+	ExpireAfterSeconds      *int64          `json:"expire_after_seconds,omitempty"`
+	PartialFilterExpression json.RawMessage `json:"partial_filter_expression,omitempty"`
+	Collation               json.RawMessage `json:"collation,omitempty"`
+	Weights                 json.RawMessage `json:"weights,omitempty"`
+	DefaultLanguage         string          `json:"default_language,omitempty"`
+	LanguageOverride        string          `json:"language_override,omitempty"`
+	WildcardProjection      json.RawMessage `json:"wildcard_projection,omitempty"`
+	Version                 int64           `json:"version,omitempty"`
+	TextIndexVersion        int64           `json:"text_index_version,omitempty"`
+	SphereIndexVersion      int64           `json:"2dsphere_index_version,omitempty"`
+	Bits                    int64           `json:"bits,omitempty"`
+	Min                     *float64        `json:"min,omitempty"`
+	Max                     *float64        `json:"max,omitempty"`
+	ExtraOptions            json.RawMessage `json:"extra_options,omitempty"`
 }
 
 type FieldStats struct {
@@ -78,9 +132,16 @@ type FieldStats struct {
 	NumericStats *NumericStats  `json:"numeric_stats,omitempty"`
 }
 
+func (s *FieldStats) Encode(format string) {
+	for i := range s.TopValues {
+		s.TopValues[i].Value = EncodeExtJSONValue(s.TopValues[i].Raw, format)
+	}
+}
+
 type ValueCount struct {
-	Value any   `json:"value"`
-	Count int64 `json:"count"`
+	Value json.RawMessage `json:"value"`
+	Raw   bson.RawValue   `json:"-"`
+	Count int64           `json:"count"`
 }
 
 type NumericStats struct {
@@ -91,7 +152,7 @@ type NumericStats struct {
 }
 
 func (r *CollectionsRepository) ListCollections(ctx context.Context, dbName string) ([]CollectionInfo, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 
 	cursor, err := db.ListCollections(ctx, bson.D{})
 	if err != nil {
@@ -121,22 +182,11 @@ func (r *CollectionsRepository) ListCollections(ctx context.Context, dbName stri
 		count, _ := db.Collection(name).EstimatedDocumentCount(ctx)
 		info.DocumentCount = count
 
-		var stats bson.M
-		if err := db.RunCommand(ctx, bson.D{{"collStats", name}}).Decode(&stats); err == nil {
-			if size, ok := stats["size"].(int32); ok {
-				info.SizeBytes = int64(size)
-			} else if size, ok := stats["size"].(int64); ok {
-				info.SizeBytes = size
-			}
-			if avgSize, ok := stats["avgObjSize"].(int32); ok {
-				info.AvgDocSize = int64(avgSize)
-			} else if avgSize, ok := stats["avgObjSize"].(int64); ok {
-				info.AvgDocSize = avgSize
-			} else if avgSize, ok := stats["avgObjSize"].(float64); ok {
-				info.AvgDocSize = int64(avgSize)
-			}
-			if nindexes, ok := stats["nindexes"].(int32); ok {
-				info.IndexCount = int(nindexes)
+		if collType != "view" {
+			if stats, err := r.runCollStats(ctx, dbName, name, collStatsOptions{}); err == nil {
+				info.SizeBytes = stats.StorageStats.Size
+				info.AvgDocSize = int64(stats.StorageStats.AvgObjSize)
+				info.IndexCount = stats.StorageStats.NIndexes
 			}
 		}
 
@@ -151,62 +201,40 @@ func (r *CollectionsRepository) ListCollections(ctx context.Context, dbName stri
 }
 
 func (r *CollectionsRepository) GetCollectionStats(ctx context.Context, dbName, collName string) (*CollectionStats, error) {
-	db := r.client.client.Database(dbName)
-
-	var stats bson.M
-	if err := db.RunCommand(ctx, bson.D{{"collStats", collName}}).Decode(&stats); err != nil {
+	stats, err := r.runCollStats(ctx, dbName, collName, collStatsOptions{latency: true, queryExec: true})
+	if err != nil {
 		return nil, fmt.Errorf("get collection stats: %w", err)
 	}
 
-	result := &CollectionStats{
-		Name: collName,
-	}
-
-	if count, ok := stats["count"].(int32); ok {
-		result.DocumentCount = int64(count)
-	} else if count, ok := stats["count"].(int64); ok {
-		result.DocumentCount = count
-	}
-
-	if size, ok := stats["size"].(int32); ok {
-		result.SizeBytes = int64(size)
-	} else if size, ok := stats["size"].(int64); ok {
-		result.SizeBytes = size
-	}
-
-	if avgSize, ok := stats["avgObjSize"].(int32); ok {
-		result.AvgDocSize = int64(avgSize)
-	} else if avgSize, ok := stats["avgObjSize"].(int64); ok {
-		result.AvgDocSize = avgSize
-	} else if avgSize, ok := stats["avgObjSize"].(float64); ok {
-		result.AvgDocSize = int64(avgSize)
-	}
-
-	if storageSize, ok := stats["storageSize"].(int32); ok {
-		result.StorageSize = int64(storageSize)
-	} else if storageSize, ok := stats["storageSize"].(int64); ok {
-		result.StorageSize = storageSize
-	}
-
-	if nindexes, ok := stats["nindexes"].(int32); ok {
-		result.IndexCount = int(nindexes)
-	}
-
-	if totalIndexSize, ok := stats["totalIndexSize"].(int32); ok {
-		result.TotalIndexSize = int64(totalIndexSize)
-	} else if totalIndexSize, ok := stats["totalIndexSize"].(int64); ok {
-		result.TotalIndexSize = totalIndexSize
-	}
-
-	if capped, ok := stats["capped"].(bool); ok {
-		result.Capped = capped
-	}
-
-	return result, nil
+	storage := stats.StorageStats
+
+	return &CollectionStats{
+		Name:            collName,
+		DocumentCount:   storage.Count,
+		SizeBytes:       storage.Size,
+		AvgDocSize:      int64(storage.AvgObjSize),
+		StorageSize:     storage.StorageSize,
+		FreeStorageSize: storage.FreeStorageSize,
+		IndexCount:      storage.NIndexes,
+		TotalIndexSize:  storage.TotalIndexSize,
+		TotalSize:       storage.TotalSize,
+		IndexSizes:      storage.IndexSizes,
+		Capped:          storage.Capped,
+		Latency: &LatencyStats{
+			Reads:        stats.LatencyStats.Reads.toOperationLatency(),
+			Writes:       stats.LatencyStats.Writes.toOperationLatency(),
+			Commands:     stats.LatencyStats.Commands.toOperationLatency(),
+			Transactions: stats.LatencyStats.Transactions.toOperationLatency(),
+		},
+		CollectionScans: &CollectionScanStats{
+			Total:       stats.QueryExecStats.CollectionScans.Total,
+			NonTailable: stats.QueryExecStats.CollectionScans.NonTailable,
+		},
+	}, nil
 }
 
 func (r *CollectionsRepository) AnalyzeSchema(ctx context.Context, dbName, collName string, sampleSize int) (*SchemaAnalysis, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 	coll := db.Collection(collName)
 
 	totalDocs, err := coll.EstimatedDocumentCount(ctx)
@@ -235,110 +263,294 @@ func (r *CollectionsRepository) AnalyzeSchema(ctx context.Context, dbName, collN
 	var sampledCount int64
 
 	for cursor.Next(ctx) {
-		var doc bson.M
-		if err := cursor.Decode(&doc); err != nil {
-			continue
-		}
 		sampledCount++
-		analyzeDocument("", doc, fieldMap)
+		analyzeDocument(cursor.Current, sampledCount, fieldMap)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sample: %w", err)
 	}
 
-	var fields []FieldSchema
+	fields := make([]FieldSchema, 0, len(fieldMap))
 	for name, info := range fieldMap {
-		typeList := make([]string, 0, len(info.types))
-		for t := range info.types {
-			typeList = append(typeList, t)
-		}
-		sort.Strings(typeList)
-
-		coverage := float64(info.count) / float64(sampledCount) * 100
-
-		samples := info.samples
-		if len(samples) > 5 {
-			samples = samples[:5]
-		}
-
-		fields = append(fields, FieldSchema{
-			Name:         name,
-			Types:        typeList,
-			Coverage:     coverage,
-			Count:        info.count,
-			TotalDocs:    sampledCount,
-			SampleValues: samples,
-		})
+		fields = append(fields, info.schema(name, sampledCount))
 	}
 
 	sort.Slice(fields, func(i, j int) bool {
-		return fields[i].Coverage > fields[j].Coverage
+		if fields[i].Coverage != fields[j].Coverage {
+			return fields[i].Coverage > fields[j].Coverage
+		}
+		return fields[i].Name < fields[j].Name
 	})
 
-	return &SchemaAnalysis{
+	analysis := &SchemaAnalysis{
 		CollectionName: collName,
 		TotalDocuments: totalDocs,
 		SampleSize:     sampledCount,
 		Fields:         fields,
-	}, nil
+	}
+	analysis.Encode(ExtJSONRelaxed)
+
+	return analysis, nil
 }
 
+const (
+	maxSchemaSamples  = 5
+	MaxDistinctValues = 50
+	ArrayPathSegment  = "[]"
+)
+
 type fieldInfo struct {
-	count   int64
-	types   map[string]bool
-	samples []any
+	docs         int64
+	lastDoc      int64
+	occurrences  int64
+	types        map[string]int64
+	samples      []bson.RawValue
+	distinct     map[string]bson.RawValue
+	manyValues   bool
+	stringLength lengthTracker
+	arrayLength  lengthTracker
+}
+
+type lengthTracker struct {
+	count int64
+	min   int64
+	max   int64
+	sum   int64
+}
+
+func (t *lengthTracker) add(n int64) {
+	if t.count == 0 || n < t.min {
+		t.min = n
+	}
+	if n > t.max {
+		t.max = n
+	}
+	t.count++
+	t.sum += n
+}
+
+func (t *lengthTracker) result() *LengthRange {
+	if t.count == 0 {
+		return nil
+	}
+	return &LengthRange{
+		Min: t.min,
+		Max: t.max,
+		Avg: float64(t.sum) / float64(t.count),
+	}
+}
+
+func (f *fieldInfo) schema(name string, sampledCount int64) FieldSchema {
+	typeCounts := make([]TypeCount, 0, len(f.types))
+	for t, count := range f.types {
+		typeCounts = append(typeCounts, TypeCount{
+			Type:       t,
+			Count:      count,
+			Percentage: float64(count) / float64(f.occurrences) * 100,
+		})
+	}
+	sort.Slice(typeCounts, func(i, j int) bool {
+		if typeCounts[i].Count != typeCounts[j].Count {
+			return typeCounts[i].Count > typeCounts[j].Count
+		}
+		return typeCounts[i].Type < typeCounts[j].Type
+	})
+
+	types := make([]string, 0, len(typeCounts))
+	for _, tc := range typeCounts {
+		types = append(types, tc.Type)
+	}
+
+	var distinct []bson.RawValue
+	if !f.manyValues && len(f.distinct) > 0 {
+		keys := make([]string, 0, len(f.distinct))
+		for key := range f.distinct {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			distinct = append(distinct, f.distinct[key])
+		}
+	}
+
+	return FieldSchema{
+		Name:         name,
+		Types:        types,
+		TypeCounts:   typeCounts,
+		Coverage:     float64(f.docs) / float64(sampledCount) * 100,
+		Count:        f.docs,
+		Occurrences:  f.occurrences,
+		TotalDocs:    sampledCount,
+		StringLength: f.stringLength.result(),
+		ArrayLength:  f.arrayLength.result(),
+		RawSamples:   f.samples,
+		Distinct:     distinct,
+	}
+}
+
+func WalkDocument(doc bson.Raw, fn func(path string, value bson.RawValue)) {
+	walkDocument("", doc, fn)
 }
 
-func analyzeDocument(prefix string, doc bson.M, fieldMap map[string]*fieldInfo) {
-	for key, value := range doc {
-		fullKey := key
+func walkDocument(prefix string, doc bson.Raw, fn func(path string, value bson.RawValue)) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return
+	}
+
+	for _, elem := range elems {
+		path := elem.Key()
 		if prefix != "" {
-			fullKey = prefix + "." + key
+			path = prefix + "." + path
 		}
+		walkValue(path, elem.Value(), fn)
+	}
+}
 
-		if _, exists := fieldMap[fullKey]; !exists {
-			fieldMap[fullKey] = &fieldInfo{
-				types:   make(map[string]bool),
-				samples: make([]any, 0, 5),
-			}
+func walkValue(path string, value bson.RawValue, fn func(path string, value bson.RawValue)) {
+	fn(path, value)
+
+	switch value.Type {
+	case bson.TypeEmbeddedDocument:
+		walkDocument(path, value.Document(), fn)
+
+	case bson.TypeArray:
+		items, err := value.Array().Values()
+		if err != nil {
+			return
+		}
+		for _, item := range items {
+			walkValue(path+"."+ArrayPathSegment, item, fn)
 		}
+	}
+}
+
+func IsArrayItemPath(path string) bool {
+	for _, segment := range strings.Split(path, ".") {
+		if segment == ArrayPathSegment {
+			return true
+		}
+	}
+	return false
+}
 
-		info := fieldMap[fullKey]
-		info.count++
-		info.types[getTypeName(value)] = true
+func analyzeDocument(doc bson.Raw, docNum int64, fieldMap map[string]*fieldInfo) {
+	WalkDocument(doc, func(path string, value bson.RawValue) {
+		analyzeValue(path, value, docNum, fieldMap)
+	})
+}
 
-		if len(info.samples) < 5 {
-			info.samples = append(info.samples, value)
+func analyzeValue(path string, value bson.RawValue, docNum int64, fieldMap map[string]*fieldInfo) {
+	info, exists := fieldMap[path]
+	if !exists {
+		info = &fieldInfo{
+			types:    make(map[string]int64),
+			samples:  make([]bson.RawValue, 0, maxSchemaSamples),
+			distinct: make(map[string]bson.RawValue),
 		}
+		fieldMap[path] = info
+	}
+
+	if info.lastDoc != docNum {
+		info.lastDoc = docNum
+		info.docs++
+	}
+	info.occurrences++
+	info.types[BSONTypeName(value.Type)]++
 
-		if nested, ok := value.(bson.M); ok {
-			analyzeDocument(fullKey, nested, fieldMap)
+	if len(info.samples) < maxSchemaSamples {
+		info.samples = append(info.samples, bson.RawValue{Type: value.Type, Value: append([]byte(nil), value.Value...)})
+	}
+	info.trackDistinct(value)
+
+	switch value.Type {
+	case bson.TypeString:
+		info.stringLength.add(int64(utf8.RuneCountInString(value.StringValue())))
+
+	case bson.TypeArray:
+		if items, err := value.Array().Values(); err == nil {
+			info.arrayLength.add(int64(len(items)))
 		}
 	}
 }
 
-func getTypeName(v any) string {
-	switch v.(type) {
-	case nil:
-		return "null"
-	case bool:
-		return "boolean"
-	case int, int32, int64:
-		return "int"
-	case float32, float64:
+func (f *fieldInfo) trackDistinct(value bson.RawValue) {
+	if f.manyValues {
+		return
+	}
+
+	switch value.Type {
+	case bson.TypeNull:
+		return
+	case bson.TypeString, bson.TypeInt32, bson.TypeInt64:
+	default:
+		f.manyValues = true
+		f.distinct = nil
+		return
+	}
+
+	key := string(rune(value.Type)) + string(value.Value)
+	if _, ok := f.distinct[key]; ok {
+		return
+	}
+	if len(f.distinct) >= MaxDistinctValues {
+		f.manyValues = true
+		f.distinct = nil
+		return
+	}
+	f.distinct[key] = bson.RawValue{Type: value.Type, Value: append([]byte(nil), value.Value...)}
+}
+
+func BSONTypeName(t bson.Type) string {
+	switch t {
+	case bson.TypeDouble:
 		return "double"
-	case string:
+	case bson.TypeString:
 		return "string"
-	case bson.ObjectID:
-		return "objectId"
-	case bson.M, bson.D:
+	case bson.TypeEmbeddedDocument:
 		return "object"
-	case bson.A, []any:
+	case bson.TypeArray:
 		return "array"
-	default:
-		return fmt.Sprintf("%T", v)
-	}
+	case bson.TypeBinary:
+		return "binData"
+	case bson.TypeUndefined:
+		return "undefined"
+	case bson.TypeObjectID:
+		return "objectId"
+	case bson.TypeBoolean:
+		return "bool"
+	case bson.TypeDateTime:
+		return "date"
+	case bson.TypeNull:
+		return "null"
+	case bson.TypeRegex:
+		return "regex"
+	case bson.TypeDBPointer:
+		return "dbPointer"
+	case bson.TypeJavaScript:
+		return "javascript"
+	case bson.TypeSymbol:
+		return "symbol"
+	case bson.TypeCodeWithScope:
+		return "javascriptWithScope"
+	case bson.TypeInt32:
+		return "int"
+	case bson.TypeTimestamp:
+		return "timestamp"
+	case bson.TypeInt64:
+		return "long"
+	case bson.TypeDecimal128:
+		return "decimal"
+	case bson.TypeMinKey:
+		return "minKey"
+	case bson.TypeMaxKey:
+		return "maxKey"
+	}
+	return t.String()
 }
 
 func (r *CollectionsRepository) GetIndexes(ctx context.Context, dbName, collName string) ([]IndexInfo, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 	coll := db.Collection(collName)
 
 	cursor, err := coll.Indexes().List(ctx)
@@ -347,18 +559,9 @@ func (r *CollectionsRepository) GetIndexes(ctx context.Context, dbName, collName
 	}
 	defer cursor.Close(ctx)
 
-	var indexStats bson.M
-	db.RunCommand(ctx, bson.D{{"collStats", collName}}).Decode(&indexStats)
-
 	indexSizes := make(map[string]int64)
-	if sizes, ok := indexStats["indexSizes"].(bson.M); ok {
-		for name, size := range sizes {
-			if s, ok := size.(int32); ok {
-				indexSizes[name] = int64(s)
-			} else if s, ok := size.(int64); ok {
-				indexSizes[name] = s
-			}
-		}
+	if stats, err := r.runCollStats(ctx, dbName, collName, collStatsOptions{}); err == nil {
+		indexSizes = stats.StorageStats.IndexSizes
 	}
 
 	var indexes []IndexInfo
@@ -373,64 +576,25 @@ func (r *CollectionsRepository) GetIndexes(ctx context.Context, dbName, collName
 		sparse, _ := idx["sparse"].(bool)
 		background, _ := idx["background"].(bool)
 
-		keys := make(map[string]int)
-		if keyDoc, ok := idx["key"].(bson.M); ok {
-			for k, v := range keyDoc {
-				if dir, ok := v.(int32); ok {
-					keys[k] = int(dir)
-				} else if dir, ok := v.(int64); ok {
-					keys[k] = int(dir)
-				} else if dir, ok := v.(float64); ok {
-					keys[k] = int(dir)
-				} else if dir, ok := v.(string); ok {
-					if dir == "text" {
-						keys[k] = 0
-					}
-				}
-			}
-		}
-
-		indexes = append(indexes, IndexInfo{
+		info := IndexInfo{
 			Name:       name,
-			Keys:       keys,
+			Keys:       decodeIndexKeys(cursor.Current),
 			Unique:     unique,
 			Sparse:     sparse,
 			Background: background,
 			SizeBytes:  indexSizes[name],
-		})
-	}
-
-	return indexes, nil
-}
-
-func (r *CollectionsRepository) SampleDocuments(ctx context.Context, dbName, collName string, limit int) ([]bson.M, error) {
-	db := r.client.client.Database(dbName)
-	coll := db.Collection(collName)
-
-	if limit <= 0 {
-		limit = 20
-	}
-
-	pipeline := mongo.Pipeline{
-		{{Key: "$sample", Value: bson.D{{Key: "size", Value: limit}}}},
-	}
-
-	cursor, err := coll.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, fmt.Errorf("sample documents: %w", err)
-	}
-	defer cursor.Close(ctx)
+			Spec:       append(bson.Raw(nil), cursor.Current...),
+		}
+		decodeIndexOptions(&info, cursor.Current)
 
-	var docs []bson.M
-	if err := cursor.All(ctx, &docs); err != nil {
-		return nil, fmt.Errorf("decode documents: %w", err)
+		indexes = append(indexes, info)
 	}
 
-	return docs, nil
+	return indexes, nil
 }
 
 func (r *CollectionsRepository) GetFieldStats(ctx context.Context, dbName, collName, fieldName string) (*FieldStats, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 	coll := db.Collection(collName)
 
 	totalDocs, err := coll.EstimatedDocumentCount(ctx)
@@ -467,15 +631,14 @@ func (r *CollectionsRepository) GetFieldStats(ctx context.Context, dbName, collN
 		defer cursor.Close(ctx)
 		var topValues []ValueCount
 		for cursor.Next(ctx) {
-			var doc bson.M
-			if err := cursor.Decode(&doc); err != nil {
+			val, err := cursor.Current.LookupErr("_id")
+			if err != nil {
 				continue
 			}
-			val := doc["_id"]
-			cnt, _ := doc["count"].(int32)
+			cnt, _ := cursor.Current.Lookup("count").AsInt64OK()
 			topValues = append(topValues, ValueCount{
-				Value: val,
-				Count: int64(cnt),
+				Raw:   bson.RawValue{Type: val.Type, Value: append([]byte(nil), val.Value...)},
+				Count: cnt,
 			})
 		}
 		result.TopValues = topValues
@@ -552,11 +715,13 @@ func (r *CollectionsRepository) GetFieldStats(ctx context.Context, dbName, collN
 		}
 	}
 
+	result.Encode(ExtJSONRelaxed)
+
 	return result, nil
 }
 
 func (r *CollectionsRepository) CountByFieldValue(ctx context.Context, dbName, collName, fieldName string, value any) (int64, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 	coll := db.Collection(collName)
 
 	filter := bson.D{{Key: fieldName, Value: value}}
@@ -569,7 +734,7 @@ func (r *CollectionsRepository) CountByFieldValue(ctx context.Context, dbName, c
 }
 
 func (r *CollectionsRepository) CountByFilter(ctx context.Context, dbName, collName string, filter bson.D) (int64, error) {
-	db := r.client.client.Database(dbName)
+	db := r.client.forContext(ctx).client.Database(dbName)
 	coll := db.Collection(collName)
 
 	count, err := coll.CountDocuments(ctx, filter)
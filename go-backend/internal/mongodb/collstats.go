@@ -0,0 +1,196 @@
+/*
+AngelaMos | 2026
+collstats.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type LatencyStats struct {
+	Reads        OperationLatency `json:"reads"`
+	Writes       OperationLatency `json:"writes"`
+	Commands     OperationLatency `json:"commands"`
+	Transactions OperationLatency `json:"transactions"`
+}
+
+type OperationLatency struct {
+	Ops         int64           `json:"ops"`
+	TotalMicros int64           `json:"total_micros"`
+	AvgMicros   float64         `json:"avg_micros"`
+	Histogram   []LatencyBucket `json:"histogram"`
+}
+
+type LatencyBucket struct {
+	Micros int64 `json:"micros"`
+	Count  int64 `json:"count"`
+}
+
+type CollectionScanStats struct {
+	Total       int64 `json:"total"`
+	NonTailable int64 `json:"non_tailable"`
+}
+
+type collStatsDoc struct {
+	Shard        string `bson:"shard"`
+	LatencyStats struct {
+		Reads        latencyDoc `bson:"reads"`
+		Writes       latencyDoc `bson:"writes"`
+		Commands     latencyDoc `bson:"commands"`
+		Transactions latencyDoc `bson:"transactions"`
+	} `bson:"latencyStats"`
+	StorageStats   storageStatsDoc `bson:"storageStats"`
+	QueryExecStats struct {
+		CollectionScans struct {
+			Total       int64 `bson:"total"`
+			NonTailable int64 `bson:"nonTailable"`
+		} `bson:"collectionScans"`
+	} `bson:"queryExecStats"`
+}
+
+type latencyDoc struct {
+	Latency   int64 `bson:"latency"`
+	Ops       int64 `bson:"ops"`
+	Histogram []struct {
+		Micros int64 `bson:"micros"`
+		Count  int64 `bson:"count"`
+	} `bson:"histogram"`
+}
+
+type storageStatsDoc struct {
+	Count           int64            `bson:"count"`
+	Size            int64            `bson:"size"`
+	AvgObjSize      float64          `bson:"avgObjSize"`
+	StorageSize     int64            `bson:"storageSize"`
+	FreeStorageSize int64            `bson:"freeStorageSize"`
+	Capped          bool             `bson:"capped"`
+	NIndexes        int              `bson:"nindexes"`
+	TotalIndexSize  int64            `bson:"totalIndexSize"`
+	TotalSize       int64            `bson:"totalSize"`
+	IndexSizes      map[string]int64 `bson:"indexSizes"`
+}
+
+type collStatsOptions struct {
+	latency   bool
+	queryExec bool
+}
+
+func (r *CollectionsRepository) runCollStats(ctx context.Context, dbName, collName string, opts collStatsOptions) (*collStatsDoc, error) {
+	stage := bson.D{{Key: "storageStats", Value: bson.D{}}}
+	if opts.latency {
+		stage = append(stage, bson.E{Key: "latencyStats", Value: bson.D{{Key: "histograms", Value: true}}})
+	}
+	if opts.queryExec {
+		stage = append(stage, bson.E{Key: "queryExecStats", Value: bson.D{}})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$collStats", Value: stage}},
+	}
+
+	cursor, err := r.client.forContext(ctx).client.Database(dbName).Collection(collName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("$collStats aggregate: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var shards []collStatsDoc
+	if err := cursor.All(ctx, &shards); err != nil {
+		return nil, fmt.Errorf("decode $collStats: %w", err)
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("$collStats returned no results for %s.%s", dbName, collName)
+	}
+
+	return mergeCollStats(shards), nil
+}
+
+func mergeCollStats(shards []collStatsDoc) *collStatsDoc {
+	if len(shards) == 1 {
+		return &shards[0]
+	}
+
+	merged := collStatsDoc{}
+	merged.StorageStats.IndexSizes = make(map[string]int64)
+
+	for i := range shards {
+		s := &shards[i]
+
+		merged.StorageStats.Count += s.StorageStats.Count
+		merged.StorageStats.Size += s.StorageStats.Size
+		merged.StorageStats.StorageSize += s.StorageStats.StorageSize
+		merged.StorageStats.FreeStorageSize += s.StorageStats.FreeStorageSize
+		merged.StorageStats.TotalIndexSize += s.StorageStats.TotalIndexSize
+		merged.StorageStats.TotalSize += s.StorageStats.TotalSize
+		merged.StorageStats.Capped = merged.StorageStats.Capped || s.StorageStats.Capped
+		if s.StorageStats.NIndexes > merged.StorageStats.NIndexes {
+			merged.StorageStats.NIndexes = s.StorageStats.NIndexes
+		}
+		for name, size := range s.StorageStats.IndexSizes {
+			merged.StorageStats.IndexSizes[name] += size
+		}
+
+		mergeLatency(&merged.LatencyStats.Reads, &s.LatencyStats.Reads)
+		mergeLatency(&merged.LatencyStats.Writes, &s.LatencyStats.Writes)
+		mergeLatency(&merged.LatencyStats.Commands, &s.LatencyStats.Commands)
+		mergeLatency(&merged.LatencyStats.Transactions, &s.LatencyStats.Transactions)
+
+		merged.QueryExecStats.CollectionScans.Total += s.QueryExecStats.CollectionScans.Total
+		merged.QueryExecStats.CollectionScans.NonTailable += s.QueryExecStats.CollectionScans.NonTailable
+	}
+
+	if merged.StorageStats.Count > 0 {
+		merged.StorageStats.AvgObjSize = float64(merged.StorageStats.Size) / float64(merged.StorageStats.Count)
+	}
+
+	return &merged
+}
+
+func mergeLatency(dst, src *latencyDoc) {
+	dst.Latency += src.Latency
+	dst.Ops += src.Ops
+
+	for _, bucket := range src.Histogram {
+		found := false
+		for i := range dst.Histogram {
+			if dst.Histogram[i].Micros == bucket.Micros {
+				dst.Histogram[i].Count += bucket.Count
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst.Histogram = append(dst.Histogram, bucket)
+		}
+	}
+}
+
+func (d *latencyDoc) toOperationLatency() OperationLatency {
+	result := OperationLatency{
+		Ops:         d.Ops,
+		TotalMicros: d.Latency,
+		Histogram:   make([]LatencyBucket, 0, len(d.Histogram)),
+	}
+	if d.Ops > 0 {
+		result.AvgMicros = float64(d.Latency) / float64(d.Ops)
+	}
+
+	for _, bucket := range d.Histogram {
+		result.Histogram = append(result.Histogram, LatencyBucket{
+			Micros: bucket.Micros,
+			Count:  bucket.Count,
+		})
+	}
+	sort.Slice(result.Histogram, func(i, j int) bool {
+		return result.Histogram[i].Micros < result.Histogram[j].Micros
+	})
+
+	return result
+}
@@ -0,0 +1,229 @@
+/*
+AngelaMos | 2026
+documents.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+type DocumentQuery struct {
+	Filter     bson.D
+	Sort       bson.D
+	Projection bson.D
+	Limit      int64
+}
+
+type AggregateQuery struct {
+	Pipeline     mongo.Pipeline
+	MaxTime      time.Duration
+	AllowDiskUse bool
+	Limit        int
+}
+
+func (r *CollectionsRepository) FindDocuments(ctx context.Context, dbName, collName string, query DocumentQuery) ([]bson.Raw, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	filter := query.Filter
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	opts := options.Find().SetLimit(query.Limit)
+	if len(query.Sort) > 0 {
+		opts.SetSort(query.Sort)
+	}
+	if len(query.Projection) > 0 {
+		opts.SetProjection(query.Projection)
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]bson.Raw, 0, query.Limit)
+	for cursor.Next(ctx) {
+		doc := make(bson.Raw, len(cursor.Current))
+		copy(doc, cursor.Current)
+		docs = append(docs, doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (r *CollectionsRepository) StreamDocuments(ctx context.Context, dbName, collName string, query DocumentQuery, fn func(doc bson.Raw) error) error {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	filter := query.Filter
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	opts := options.Find().SetBatchSize(1000)
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+	if len(query.Sort) > 0 {
+		opts.SetSort(query.Sort)
+	}
+	if len(query.Projection) > 0 {
+		opts.SetProjection(query.Projection)
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("find documents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("iterate documents: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CollectionsRepository) BulkWriteDocuments(ctx context.Context, dbName, collName string, models []mongo.WriteModel) (*mongo.BulkWriteResult, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	result, err := coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return result, fmt.Errorf("bulk write documents: %w", err)
+	}
+	return result, nil
+}
+
+func (r *CollectionsRepository) AggregateDocuments(ctx context.Context, dbName, collName string, query AggregateQuery) ([]bson.Raw, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	opts := options.Aggregate().SetAllowDiskUse(query.AllowDiskUse)
+	if query.MaxTime > 0 {
+		opts.SetCustom(bson.M{"maxTimeMS": query.MaxTime.Milliseconds()})
+	}
+	if query.Limit > 0 && query.Limit < 1000 {
+		opts.SetBatchSize(int32(query.Limit))
+	}
+
+	cursor, err := coll.Aggregate(ctx, query.Pipeline, opts)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]bson.Raw, 0)
+	for (query.Limit <= 0 || len(docs) < query.Limit) && cursor.Next(ctx) {
+		docs = append(docs, append(bson.Raw(nil), cursor.Current...))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate aggregate results: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (r *CollectionsRepository) CountDocuments(ctx context.Context, dbName, collName string, filter bson.D) (int64, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	if len(filter) == 0 {
+		count, err := coll.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("estimate document count: %w", err)
+		}
+		return count, nil
+	}
+
+	count, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("count documents: %w", err)
+	}
+	return count, nil
+}
+
+func (r *CollectionsRepository) GetDocument(ctx context.Context, dbName, collName string, id bson.RawValue) (bson.Raw, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	raw, err := coll.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Raw()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find document: %w", err)
+	}
+
+	return append(bson.Raw(nil), raw...), nil
+}
+
+func (r *CollectionsRepository) ReplaceDocument(ctx context.Context, dbName, collName string, current bson.Raw, replacement bson.D) (bson.Raw, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	opts := options.FindOneAndReplace().SetReturnDocument(options.After)
+	raw, err := coll.FindOneAndReplace(ctx, unchangedFilter(current), replacement, opts).Raw()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("replace document: %w", err)
+	}
+
+	return append(bson.Raw(nil), raw...), nil
+}
+
+func (r *CollectionsRepository) UpdateDocument(ctx context.Context, dbName, collName string, current bson.Raw, update bson.D) (bson.Raw, error) {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	raw, err := coll.FindOneAndUpdate(ctx, unchangedFilter(current), update, opts).Raw()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update document: %w", err)
+	}
+
+	return append(bson.Raw(nil), raw...), nil
+}
+
+func (r *CollectionsRepository) DeleteDocument(ctx context.Context, dbName, collName string, current bson.Raw) error {
+	coll := r.client.forContext(ctx).client.Database(dbName).Collection(collName)
+
+	result, err := coll.DeleteOne(ctx, unchangedFilter(current))
+	if err != nil {
+		return fmt.Errorf("delete document: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrDocumentNotFound
+	}
+
+	return nil
+}
+
+func unchangedFilter(current bson.Raw) bson.D {
+	return bson.D{
+		{Key: "_id", Value: current.Lookup("_id")},
+		{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{
+			"$$ROOT",
+			bson.D{{Key: "$literal", Value: current}},
+		}}}},
+	}
+}
@@ -0,0 +1,348 @@
+/*
+AngelaMos | 2026
+explain.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrProfileEntryNotFound = errors.New("profile entry not found")
+
+const (
+	VerbosityQueryPlanner      = "queryPlanner"
+	VerbosityExecutionStats    = "executionStats"
+	VerbosityAllPlansExecution = "allPlansExecution"
+)
+
+type ExplainRepository struct {
+	client *Client
+}
+
+func NewExplainRepository(client *Client) *ExplainRepository {
+	return &ExplainRepository{client: client}
+}
+
+type ExplainResult struct {
+	Namespace     string          `json:"namespace"`
+	Verbosity     string          `json:"verbosity"`
+	Command       json.RawMessage `json:"command"`
+	WinningPlan   *PlanStage      `json:"winning_plan"`
+	RejectedPlans []*PlanStage    `json:"rejected_plans"`
+	IndexesUsed   []string        `json:"indexes_used"`
+	Execution     *ExecutionStats `json:"execution,omitempty"`
+	Pipeline      []PipelineStage `json:"pipeline,omitempty"`
+	QueryHash     string          `json:"query_hash,omitempty"`
+	PlanCacheKey  string          `json:"plan_cache_key,omitempty"`
+	Raw           json.RawMessage `json:"raw"`
+}
+
+type PlanStage struct {
+	Stage               string          `json:"stage"`
+	IndexName           string          `json:"index_name,omitempty"`
+	KeyPattern          json.RawMessage `json:"key_pattern,omitempty"`
+	Direction           string          `json:"direction,omitempty"`
+	IsMultiKey          bool            `json:"is_multi_key,omitempty"`
+	IndexBounds         json.RawMessage `json:"index_bounds,omitempty"`
+	Filter              json.RawMessage `json:"filter,omitempty"`
+	NReturned           int64           `json:"n_returned"`
+	KeysExamined        int64           `json:"keys_examined"`
+	DocsExamined        int64           `json:"docs_examined"`
+	ExecutionTimeMillis int64           `json:"execution_time_millis"`
+	Works               int64           `json:"works"`
+	Shard               string          `json:"shard,omitempty"`
+	Inputs              []*PlanStage    `json:"inputs,omitempty"`
+}
+
+type ExecutionStats struct {
+	Success             bool  `json:"success"`
+	NReturned           int64 `json:"n_returned"`
+	ExecutionTimeMillis int64 `json:"execution_time_millis"`
+	TotalKeysExamined   int64 `json:"total_keys_examined"`
+	TotalDocsExamined   int64 `json:"total_docs_examined"`
+}
+
+type PipelineStage struct {
+	Name                string `json:"name"`
+	NReturned           int64  `json:"n_returned"`
+	ExecutionTimeMillis int64  `json:"execution_time_millis"`
+}
+
+func (r *ExplainRepository) Explain(ctx context.Context, dbName string, cmd bson.D, verbosity string) (*ExplainResult, error) {
+	explainCmd := bson.D{
+		{Key: "explain", Value: cmd},
+		{Key: "verbosity", Value: verbosity},
+	}
+
+	raw, err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, explainCmd).Raw()
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+
+	result := normalizeExplain(raw)
+	result.Verbosity = verbosity
+	if result.Namespace == "" && len(cmd) > 0 {
+		if coll, ok := cmd[0].Value.(string); ok {
+			result.Namespace = dbName + "." + coll
+		}
+	}
+	if out, err := bson.MarshalExtJSON(cmd, false, false); err == nil {
+		result.Command = out
+	}
+
+	return result, nil
+}
+
+func (r *ExplainRepository) GetProfileEntry(ctx context.Context, dbName, namespace string, ts time.Time) (bson.Raw, error) {
+	filter := bson.D{
+		{Key: "ns", Value: namespace},
+		{Key: "ts", Value: ts},
+	}
+
+	raw, err := r.client.forContext(ctx).Database(dbName).Collection("system.profile").FindOne(ctx, filter).Raw()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrProfileEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find profile entry: %w", err)
+	}
+	return raw, nil
+}
+
+func normalizeExplain(raw bson.Raw) *ExplainResult {
+	result := &ExplainResult{
+		RejectedPlans: make([]*PlanStage, 0),
+		IndexesUsed:   make([]string, 0),
+		Raw:           EncodeExtJSON(raw, ExtJSONRelaxed),
+	}
+
+	planner, execution := raw, raw
+	if stages, ok := lookupArray(raw, "stages"); ok {
+		result.Pipeline = pipelineStages(stages)
+		if cursor, ok := firstCursorStage(stages); ok {
+			planner, execution = cursor, cursor
+		}
+	}
+
+	if qp, ok := lookupDocument(planner, "queryPlanner"); ok {
+		result.Namespace = lookupString(qp, "namespace")
+		result.QueryHash = lookupString(qp, "queryHash")
+		result.PlanCacheKey = lookupString(qp, "planCacheKey")
+
+		if winning, ok := lookupDocument(qp, "winningPlan"); ok {
+			result.WinningPlan = parsePlanStage(unwrapQueryPlan(winning))
+		}
+		if rejected, ok := lookupArray(qp, "rejectedPlans"); ok {
+			values, _ := rejected.Values()
+			for _, v := range values {
+				if v.Type == bson.TypeEmbeddedDocument {
+					result.RejectedPlans = append(result.RejectedPlans, parsePlanStage(unwrapQueryPlan(v.Document())))
+				}
+			}
+		}
+	}
+
+	if es, ok := lookupDocument(execution, "executionStats"); ok {
+		result.Execution = &ExecutionStats{
+			Success:             lookupBool(es, "executionSuccess"),
+			NReturned:           lookupInt(es, "nReturned"),
+			ExecutionTimeMillis: lookupInt(es, "executionTimeMillis"),
+			TotalKeysExamined:   lookupInt(es, "totalKeysExamined"),
+			TotalDocsExamined:   lookupInt(es, "totalDocsExamined"),
+		}
+		if stages, ok := lookupDocument(es, "executionStages"); ok {
+			result.WinningPlan = parsePlanStage(stages)
+		}
+	}
+
+	seen := make(map[string]bool)
+	collectIndexes(result.WinningPlan, seen)
+	for name := range seen {
+		result.IndexesUsed = append(result.IndexesUsed, name)
+	}
+	sort.Strings(result.IndexesUsed)
+
+	return result
+}
+
+func parsePlanStage(doc bson.Raw) *PlanStage {
+	stage := &PlanStage{
+		Stage:               lookupString(doc, "stage"),
+		IndexName:           lookupString(doc, "indexName"),
+		Direction:           lookupString(doc, "direction"),
+		IsMultiKey:          lookupBool(doc, "isMultiKey"),
+		NReturned:           lookupInt(doc, "nReturned"),
+		KeysExamined:        lookupInt(doc, "keysExamined"),
+		DocsExamined:        lookupInt(doc, "docsExamined"),
+		ExecutionTimeMillis: lookupInt(doc, "executionTimeMillisEstimate"),
+		Works:               lookupInt(doc, "works"),
+		Shard:               lookupString(doc, "shardName"),
+	}
+
+	if v, ok := lookupDocument(doc, "keyPattern"); ok {
+		stage.KeyPattern = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+	if v, ok := lookupDocument(doc, "indexBounds"); ok {
+		stage.IndexBounds = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+	if v, ok := lookupDocument(doc, "filter"); ok {
+		stage.Filter = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+
+	if input, ok := lookupDocument(doc, "inputStage"); ok {
+		stage.Inputs = append(stage.Inputs, parsePlanStage(input))
+	}
+	for _, key := range []string{"inputStages", "shards"} {
+		inputs, ok := lookupArray(doc, key)
+		if !ok {
+			continue
+		}
+		values, _ := inputs.Values()
+		for _, v := range values {
+			if v.Type != bson.TypeEmbeddedDocument {
+				continue
+			}
+			child := v.Document()
+			if key == "shards" {
+				child = shardPlan(child)
+			}
+			stage.Inputs = append(stage.Inputs, parsePlanStage(child))
+		}
+	}
+
+	return stage
+}
+
+func shardPlan(shard bson.Raw) bson.Raw {
+	plan := shard
+	if v, ok := lookupDocument(shard, "executionStages"); ok {
+		plan = v
+	} else if v, ok := lookupDocument(shard, "winningPlan"); ok {
+		plan = unwrapQueryPlan(v)
+	}
+
+	if lookupString(plan, "shardName") != "" {
+		return plan
+	}
+
+	name := lookupString(shard, "shardName")
+	if name == "" {
+		return plan
+	}
+
+	doc := bson.D{{Key: "shardName", Value: name}}
+	elements, _ := plan.Elements()
+	for _, e := range elements {
+		doc = append(doc, bson.E{Key: e.Key(), Value: e.Value()})
+	}
+	out, err := bson.Marshal(doc)
+	if err != nil {
+		return plan
+	}
+	return out
+}
+
+func unwrapQueryPlan(plan bson.Raw) bson.Raw {
+	if inner, ok := lookupDocument(plan, "queryPlan"); ok {
+		return inner
+	}
+	return plan
+}
+
+func firstCursorStage(stages bson.RawArray) (bson.Raw, bool) {
+	values, err := stages.Values()
+	if err != nil || len(values) == 0 || values[0].Type != bson.TypeEmbeddedDocument {
+		return nil, false
+	}
+	return lookupDocument(values[0].Document(), "$cursor")
+}
+
+func pipelineStages(stages bson.RawArray) []PipelineStage {
+	values, err := stages.Values()
+	if err != nil {
+		return nil
+	}
+
+	out := make([]PipelineStage, 0, len(values))
+	for _, v := range values {
+		if v.Type != bson.TypeEmbeddedDocument {
+			continue
+		}
+		doc := v.Document()
+		elements, err := doc.Elements()
+		if err != nil || len(elements) == 0 {
+			continue
+		}
+		out = append(out, PipelineStage{
+			Name:                elements[0].Key(),
+			NReturned:           lookupInt(doc, "nReturned"),
+			ExecutionTimeMillis: lookupInt(doc, "executionTimeMillisEstimate"),
+		})
+	}
+	return out
+}
+
+func collectIndexes(stage *PlanStage, seen map[string]bool) {
+	if stage == nil {
+		return
+	}
+	if stage.IndexName != "" {
+		seen[stage.IndexName] = true
+	}
+	for _, input := range stage.Inputs {
+		collectIndexes(input, seen)
+	}
+}
+
+func lookupDocument(doc bson.Raw, key string) (bson.Raw, bool) {
+	v, err := doc.LookupErr(key)
+	if err != nil || v.Type != bson.TypeEmbeddedDocument {
+		return nil, false
+	}
+	return v.Document(), true
+}
+
+func lookupArray(doc bson.Raw, key string) (bson.RawArray, bool) {
+	v, err := doc.LookupErr(key)
+	if err != nil || v.Type != bson.TypeArray {
+		return nil, false
+	}
+	return v.Array(), true
+}
+
+func lookupString(doc bson.Raw, key string) string {
+	v, err := doc.LookupErr(key)
+	if err != nil {
+		return ""
+	}
+	s, _ := v.StringValueOK()
+	return s
+}
+
+func lookupBool(doc bson.Raw, key string) bool {
+	v, err := doc.LookupErr(key)
+	if err != nil {
+		return false
+	}
+	b, _ := v.BooleanOK()
+	return b
+}
+
+func lookupInt(doc bson.Raw, key string) int64 {
+	v, err := doc.LookupErr(key)
+	if err != nil {
+		return 0
+	}
+	n, _ := v.AsInt64OK()
+	return n
+}
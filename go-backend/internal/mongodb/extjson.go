@@ -0,0 +1,102 @@
+/*
+AngelaMos | 2026
+extjson.go
+*/
+
+package mongodb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	ExtJSONRelaxed   = "relaxed"
+	ExtJSONCanonical = "canonical"
+)
+
+func ValidExtJSONFormat(format string) bool {
+	return format == ExtJSONRelaxed || format == ExtJSONCanonical
+}
+
+func EncodeExtJSON(raw bson.Raw, format string) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	out, err := bson.MarshalExtJSON(raw, format == ExtJSONCanonical, false)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func EncodeExtJSONValue(value bson.RawValue, format string) json.RawMessage {
+	if value.Type == 0 {
+		return json.RawMessage("null")
+	}
+
+	wrapped, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, format == ExtJSONCanonical, false)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+
+	var doc struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(wrapped, &doc); err != nil {
+		return json.RawMessage("null")
+	}
+	return doc.V
+}
+
+func ParseExtJSONDocument(data []byte) (bson.D, error) {
+	doc := bson.D{}
+	if len(data) == 0 || string(data) == "null" {
+		return doc, nil
+	}
+
+	if err := bson.UnmarshalExtJSON(data, false, &doc); err != nil {
+		return nil, fmt.Errorf("parse extended json document: %w", err)
+	}
+	return doc, nil
+}
+
+func ParseExtJSONValue(data []byte) (bson.RawValue, error) {
+	if !json.Valid(data) {
+		return bson.RawValue{}, fmt.Errorf("parse extended json value: invalid json")
+	}
+
+	wrapped := make([]byte, 0, len(data)+6)
+	wrapped = append(wrapped, `{"v":`...)
+	wrapped = append(wrapped, data...)
+	wrapped = append(wrapped, '}')
+
+	var doc bson.Raw
+	if err := bson.UnmarshalExtJSON(wrapped, false, &doc); err != nil {
+		return bson.RawValue{}, fmt.Errorf("parse extended json value: %w", err)
+	}
+	return doc.Lookup("v"), nil
+}
+
+func ParseExtJSONPipeline(data []byte) (mongo.Pipeline, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return mongo.Pipeline{}, nil
+	}
+
+	wrapped := make([]byte, 0, len(data)+14)
+	wrapped = append(wrapped, `{"pipeline":`...)
+	wrapped = append(wrapped, data...)
+	wrapped = append(wrapped, '}')
+
+	var doc struct {
+		Pipeline []bson.D `bson:"pipeline"`
+	}
+	if err := bson.UnmarshalExtJSON(wrapped, false, &doc); err != nil {
+		return nil, fmt.Errorf("parse extended json pipeline: %w", err)
+	}
+	return mongo.Pipeline(doc.Pipeline), nil
+}
@@ -0,0 +1,274 @@
+/*
+AngelaMos | 2026
+indexes.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrIndexNotFound = errors.New("index not found")
+
+const (
+	IndexKeyAscending   = "ascending"
+	IndexKeyDescending  = "descending"
+	IndexKeyText        = "text"
+	IndexKeyGeo2D       = "2d"
+	IndexKeyGeo2DSphere = "2dsphere"
+	IndexKeyGeoHaystack = "geoHaystack"
+	IndexKeyHashed      = "hashed"
+	IndexKeyWildcard    = "wildcard"
+)
+
+var knownIndexOptions = map[string]bool{
+	"v":                       true,
+	"key":                     true,
+	"name":                    true,
+	"ns":                      true,
+	"unique":                  true,
+	"sparse":                  true,
+	"background":              true,
+	"hidden":                  true,
+	"expireAfterSeconds":      true,
+	"partialFilterExpression": true,
+	"collation":               true,
+	"weights":                 true,
+	"default_language":        true,
+	"language_override":       true,
+	"textIndexVersion":        true,
+	"2dsphereIndexVersion":    true,
+	"wildcardProjection":      true,
+	"bits":                    true,
+	"min":                     true,
+	"max":                     true,
+}
+
+type IndexKeyField struct {
+	Field     string          `json:"field"`
+	Type      string          `json:"type"`
+	Direction int             `json:"direction,omitempty"`
+	Value     json.RawMessage `json:"value"`
+}
+
+type IndexBuildOp struct {
+	OpID        int64     `json:"opid"`
+	Namespace   string    `json:"namespace"`
+	Indexes     []string  `json:"indexes"`
+	Message     string    `json:"message,omitempty"`
+	Done        int64     `json:"done"`
+	Total       int64     `json:"total"`
+	SecsRunning int64     `json:"secs_running"`
+	ObservedAt  time.Time `json:"observed_at"`
+}
+
+func (r *CollectionsRepository) GetIndex(ctx context.Context, dbName, collName, indexName string) (*IndexInfo, error) {
+	indexes, err := r.GetIndexes(ctx, dbName, collName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range indexes {
+		if indexes[i].Name == indexName {
+			return &indexes[i], nil
+		}
+	}
+	return nil, ErrIndexNotFound
+}
+
+func (r *CollectionsRepository) CreateIndex(ctx context.Context, dbName, collName string, spec bson.D) error {
+	cmd := bson.D{
+		{Key: "createIndexes", Value: collName},
+		{Key: "indexes", Value: bson.A{spec}},
+	}
+
+	if err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionsRepository) DropIndex(ctx context.Context, dbName, collName, indexName string) error {
+	cmd := bson.D{
+		{Key: "dropIndexes", Value: collName},
+		{Key: "index", Value: indexName},
+	}
+
+	if err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("drop index: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionsRepository) SetIndexHidden(ctx context.Context, dbName, collName, indexName string, hidden bool) error {
+	cmd := bson.D{
+		{Key: "collMod", Value: collName},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: indexName},
+			{Key: "hidden", Value: hidden},
+		}},
+	}
+
+	if err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("collMod index: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionsRepository) GetIndexBuilds(ctx context.Context, namespace string) ([]IndexBuildOp, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{
+			{Key: "allUsers", Value: true},
+			{Key: "idleConnections", Value: false},
+		}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "ns", Value: namespace},
+			{Key: "command.createIndexes", Value: bson.D{{Key: "$exists", Value: true}}},
+		}}},
+	}
+
+	cursor, err := r.client.forContext(ctx).Database("admin").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("currentOp aggregate: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	now := time.Now()
+	builds := make([]IndexBuildOp, 0)
+	for cursor.Next(ctx) {
+		doc := cursor.Current
+
+		build := IndexBuildOp{
+			OpID:        lookupInt(doc, "opid"),
+			Namespace:   lookupString(doc, "ns"),
+			Indexes:     make([]string, 0),
+			Message:     lookupString(doc, "msg"),
+			SecsRunning: lookupInt(doc, "secs_running"),
+			ObservedAt:  now,
+		}
+		if progress, ok := lookupDocument(doc, "progress"); ok {
+			build.Done = lookupInt(progress, "done")
+			build.Total = lookupInt(progress, "total")
+		}
+		if command, ok := lookupDocument(doc, "command"); ok {
+			if specs, ok := lookupArray(command, "indexes"); ok {
+				values, _ := specs.Values()
+				for _, v := range values {
+					if v.Type == bson.TypeEmbeddedDocument {
+						build.Indexes = append(build.Indexes, lookupString(v.Document(), "name"))
+					}
+				}
+			}
+		}
+
+		builds = append(builds, build)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate currentOp: %w", err)
+	}
+
+	return builds, nil
+}
+
+func decodeIndexKeys(spec bson.Raw) []IndexKeyField {
+	keys := make([]IndexKeyField, 0)
+
+	keyDoc, ok := lookupDocument(spec, "key")
+	if !ok {
+		return keys
+	}
+
+	elements, err := keyDoc.Elements()
+	if err != nil {
+		return keys
+	}
+
+	for _, e := range elements {
+		v := e.Value()
+		key := IndexKeyField{
+			Field: e.Key(),
+			Value: EncodeExtJSONValue(v, ExtJSONRelaxed),
+		}
+
+		if typ, ok := v.StringValueOK(); ok {
+			key.Type = typ
+		} else if n, ok := numericValue(v); ok {
+			key.Direction = 1
+			key.Type = IndexKeyAscending
+			if n < 0 {
+				key.Direction = -1
+				key.Type = IndexKeyDescending
+			}
+			if key.Field == "$**" || strings.HasSuffix(key.Field, ".$**") {
+				key.Type = IndexKeyWildcard
+			}
+		}
+
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func decodeIndexOptions(info *IndexInfo, spec bson.Raw) {
+	info.Hidden = lookupBool(spec, "hidden")
+	info.Version = lookupInt(spec, "v")
+	info.TextIndexVersion = lookupInt(spec, "textIndexVersion")
+	info.SphereIndexVersion = lookupInt(spec, "2dsphereIndexVersion")
+	info.Bits = lookupInt(spec, "bits")
+
+	for _, bound := range []struct {
+		key string
+		dst **float64
+	}{{"min", &info.Min}, {"max", &info.Max}} {
+		if v, err := spec.LookupErr(bound.key); err == nil {
+			if n, ok := numericValue(v); ok {
+				*bound.dst = &n
+			}
+		}
+	}
+
+	extra := bson.D{}
+	if elements, err := spec.Elements(); err == nil {
+		for _, e := range elements {
+			if !knownIndexOptions[e.Key()] {
+				extra = append(extra, bson.E{Key: e.Key(), Value: e.Value()})
+			}
+		}
+	}
+	if len(extra) > 0 {
+		if out, err := bson.MarshalExtJSON(extra, false, false); err == nil {
+			info.ExtraOptions = out
+		}
+	}
+
+	info.DefaultLanguage = lookupString(spec, "default_language")
+	info.LanguageOverride = lookupString(spec, "language_override")
+
+	if v, err := spec.LookupErr("expireAfterSeconds"); err == nil {
+		if seconds, ok := v.AsInt64OK(); ok {
+			info.ExpireAfterSeconds = &seconds
+		}
+	}
+	if v, ok := lookupDocument(spec, "partialFilterExpression"); ok {
+		info.PartialFilterExpression = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+	if v, ok := lookupDocument(spec, "collation"); ok {
+		info.Collation = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+	if v, ok := lookupDocument(spec, "weights"); ok {
+		info.Weights = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+	if v, ok := lookupDocument(spec, "wildcardProjection"); ok {
+		info.WildcardProjection = EncodeExtJSON(v, ExtJSONRelaxed)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+AngelaMos | 2026
+indexstats.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type IndexUsage struct {
+	Ops   int64     `json:"ops"`
+	Since time.Time `json:"since"`
+	Hosts int       `json:"hosts"`
+}
+
+type indexStatsDoc struct {
+	Name     string `bson:"name"`
+	Host     string `bson:"host"`
+	Accesses struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
+
+func (r *CollectionsRepository) GetIndexStats(ctx context.Context, dbName, collName string) (map[string]IndexUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$indexStats", Value: bson.D{}}},
+	}
+
+	cursor, err := r.client.forContext(ctx).client.Database(dbName).Collection(collName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("$indexStats aggregate: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []indexStatsDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode $indexStats: %w", err)
+	}
+
+	usage := make(map[string]IndexUsage, len(docs))
+	for _, doc := range docs {
+		u := usage[doc.Name]
+		u.Ops += doc.Accesses.Ops
+		if u.Since.IsZero() || doc.Accesses.Since.After(u.Since) {
+			u.Since = doc.Accesses.Since
+		}
+		u.Hosts++
+		usage[doc.Name] = u
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,73 @@
+/*
+AngelaMos | 2026
+kpi.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrNoKPIValue = errors.New("aggregation returned no numeric value field")
+
+type KPIRepository struct {
+	client *Client
+}
+
+func NewKPIRepository(client *Client) *KPIRepository {
+	return &KPIRepository{client: client}
+}
+
+func (r *KPIRepository) Count(ctx context.Context, dbName, collName string, filter bson.D) (int64, error) {
+	count, err := r.client.forContext(ctx).Database(dbName).Collection(collName).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("count %s.%s: %w", dbName, collName, err)
+	}
+	return count, nil
+}
+
+func (r *KPIRepository) Aggregate(ctx context.Context, dbName, collName string, pipeline mongo.Pipeline) (float64, error) {
+	cursor, err := r.client.forContext(ctx).Database(dbName).Collection(collName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("aggregate %s.%s: %w", dbName, collName, err)
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return 0, fmt.Errorf("iterate aggregate: %w", err)
+		}
+		return 0, nil
+	}
+
+	value, ok := numericValue(cursor.Current.Lookup("value"))
+	if !ok {
+		return 0, ErrNoKPIValue
+	}
+	return value, nil
+}
+
+func numericValue(rv bson.RawValue) (float64, bool) {
+	switch rv.Type {
+	case bson.TypeInt32:
+		return float64(rv.Int32()), true
+	case bson.TypeInt64:
+		return float64(rv.Int64()), true
+	case bson.TypeDouble:
+		return rv.Double(), true
+	case bson.TypeDecimal128:
+		value, err := strconv.ParseFloat(rv.Decimal128().String(), 64)
+		if err != nil {
+			return 0, false
+		}
+		return value, true
+	}
+	return 0, false
+}
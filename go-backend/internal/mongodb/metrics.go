@@ -12,6 +12,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type MetricsRepository struct {
@@ -49,6 +50,54 @@ type ServerStatus struct {
 		BytesOut    int64 `bson:"bytesOut"`
 		NumRequests int64 `bson:"numRequests"`
 	} `bson:"network"`
+	StorageEngine struct {
+		Name string `bson:"name"`
+	} `bson:"storageEngine"`
+	GlobalLock struct {
+		TotalTime     int64      `bson:"totalTime"`
+		CurrentQueue  LockCounts `bson:"currentQueue"`
+		ActiveClients LockCounts `bson:"activeClients"`
+	} `bson:"globalLock"`
+	WiredTiger *WiredTigerStatus `bson:"wiredTiger"`
+	Queues     struct {
+		Execution TicketQueues `bson:"execution"`
+	} `bson:"queues"`
+	Metrics struct {
+		Document struct {
+			Deleted  int64 `bson:"deleted"`
+			Inserted int64 `bson:"inserted"`
+			Returned int64 `bson:"returned"`
+			Updated  int64 `bson:"updated"`
+		} `bson:"document"`
+	} `bson:"metrics"`
+}
+
+type LockCounts struct {
+	Total   int64 `bson:"total"`
+	Readers int64 `bson:"readers"`
+	Writers int64 `bson:"writers"`
+}
+
+type WiredTigerStatus struct {
+	Cache struct {
+		BytesInCache       int64 `bson:"bytes currently in the cache"`
+		MaxBytesConfigured int64 `bson:"maximum bytes configured"`
+		TrackedDirtyBytes  int64 `bson:"tracked dirty bytes in the cache"`
+		PagesReadInto      int64 `bson:"pages read into cache"`
+		PagesWrittenFrom   int64 `bson:"pages written from cache"`
+	} `bson:"cache"`
+	ConcurrentTransactions TicketQueues `bson:"concurrentTransactions"`
+}
+
+type TicketQueues struct {
+	Read  TicketCounts `bson:"read"`
+	Write TicketCounts `bson:"write"`
+}
+
+type TicketCounts struct {
+	Out          int64 `bson:"out"`
+	Available    int64 `bson:"available"`
+	TotalTickets int64 `bson:"totalTickets"`
 }
 
 type DatabaseStats struct {
@@ -79,7 +128,7 @@ type Operation struct {
 
 func (r *MetricsRepository) GetServerStatus(ctx context.Context) (*ServerStatus, error) {
 	var result ServerStatus
-	err := r.client.Database("admin").RunCommand(ctx, bson.D{{"serverStatus", 1}}).Decode(&result)
+	err := r.client.forContext(ctx).Database("admin").RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&result)
 	if err != nil {
 		return nil, fmt.Errorf("serverStatus command: %w", err)
 	}
@@ -88,7 +137,7 @@ func (r *MetricsRepository) GetServerStatus(ctx context.Context) (*ServerStatus,
 
 func (r *MetricsRepository) GetDatabaseStats(ctx context.Context, dbName string) (*DatabaseStats, error) {
 	var result DatabaseStats
-	err := r.client.Database(dbName).RunCommand(ctx, bson.D{{"dbStats", 1}}).Decode(&result)
+	err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, bson.D{{Key: "dbStats", Value: 1}}).Decode(&result)
 	if err != nil {
 		return nil, fmt.Errorf("dbStats command: %w", err)
 	}
@@ -106,7 +155,7 @@ func (r *MetricsRepository) GetCurrentOps(ctx context.Context) ([]Operation, err
 		}}},
 	}
 
-	cursor, err := r.client.Database("admin").Aggregate(ctx, pipeline)
+	cursor, err := r.client.forContext(ctx).Database("admin").Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("currentOp aggregate: %w", err)
 	}
@@ -120,8 +169,56 @@ func (r *MetricsRepository) GetCurrentOps(ctx context.Context) ([]Operation, err
 	return ops, nil
 }
 
+type TopCounter struct {
+	Time  int64 `bson:"time"`
+	Count int64 `bson:"count"`
+}
+
+type TopStats struct {
+	Total     TopCounter `bson:"total"`
+	ReadLock  TopCounter `bson:"readLock"`
+	WriteLock TopCounter `bson:"writeLock"`
+	Queries   TopCounter `bson:"queries"`
+	Getmore   TopCounter `bson:"getmore"`
+	Insert    TopCounter `bson:"insert"`
+	Update    TopCounter `bson:"update"`
+	Remove    TopCounter `bson:"remove"`
+	Commands  TopCounter `bson:"commands"`
+}
+
+func (r *MetricsRepository) GetTop(ctx context.Context) (map[string]TopStats, error) {
+	var result struct {
+		Totals bson.Raw `bson:"totals"`
+	}
+	err := r.client.forContext(ctx).Database("admin").RunCommand(ctx, bson.D{{Key: "top", Value: 1}}).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("top command: %w", err)
+	}
+
+	elements, err := result.Totals.Elements()
+	if err != nil {
+		return nil, fmt.Errorf("read top totals: %w", err)
+	}
+
+	stats := make(map[string]TopStats, len(elements))
+	for _, elem := range elements {
+		value := elem.Value()
+		if value.Type != bson.TypeEmbeddedDocument {
+			continue
+		}
+
+		var ns TopStats
+		if err := value.Unmarshal(&ns); err != nil {
+			continue
+		}
+		stats[elem.Key()] = ns
+	}
+
+	return stats, nil
+}
+
 func (r *MetricsRepository) ListDatabases(ctx context.Context) ([]string, error) {
-	result, err := r.client.Client().ListDatabaseNames(ctx, bson.D{})
+	result, err := r.client.forContext(ctx).Client().ListDatabaseNames(ctx, bson.D{})
 	if err != nil {
 		return nil, fmt.Errorf("list databases: %w", err)
 	}
@@ -129,7 +226,7 @@ func (r *MetricsRepository) ListDatabases(ctx context.Context) ([]string, error)
 }
 
 func (r *MetricsRepository) GetCollectionCount(ctx context.Context, dbName string) (int, error) {
-	collections, err := r.client.Database(dbName).ListCollectionNames(ctx, bson.D{})
+	collections, err := r.client.forContext(ctx).Database(dbName).ListCollectionNames(ctx, bson.D{})
 	if err != nil {
 		return 0, fmt.Errorf("list collections: %w", err)
 	}
@@ -144,8 +241,11 @@ type SlowQuery struct {
 	PlanSummary  string    `bson:"planSummary" json:"plan_summary"`
 	Command      bson.Raw  `bson:"command" json:"command,omitempty"`
 	Query        bson.Raw  `bson:"query" json:"query,omitempty"`
+	Originating  bson.Raw  `bson:"originatingCommand" json:"-"`
 	KeysExamined int64     `bson:"keysExamined" json:"keys_examined"`
 	DocsExamined int64     `bson:"docsExamined" json:"docs_examined"`
+	NReturned    int64     `bson:"nreturned" json:"nreturned"`
+	HasSortStage bool      `bson:"hasSortStage" json:"has_sort_stage"`
 	NumYields    int       `bson:"numYield" json:"num_yields"`
 	ResponseLen  int       `bson:"responseLength" json:"response_length"`
 	Client       string    `bson:"client" json:"client"`
@@ -168,27 +268,33 @@ func (r *MetricsRepository) GetSlowQueries(ctx context.Context, dbName string, m
 		limit = 50
 	}
 
-	coll := r.client.Database(dbName).Collection("system.profile")
+	coll := r.client.forContext(ctx).Database(dbName).Collection("system.profile")
 
 	filter := bson.D{
 		{Key: "millis", Value: bson.D{{Key: "$gte", Value: minMillis}}},
 	}
 
-	cursor, err := coll.Find(ctx, filter)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "ts", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := coll.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("query system.profile: %w", err)
 	}
 	defer cursor.Close(ctx)
 
 	var queries []SlowQuery
-	count := 0
-	for cursor.Next(ctx) && count < limit {
+	for cursor.Next(ctx) {
 		var q SlowQuery
 		if err := cursor.Decode(&q); err != nil {
-			continue
+			return nil, fmt.Errorf("decode slow query: %w", err)
 		}
 		queries = append(queries, q)
-		count++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate system.profile: %w", err)
 	}
 
 	return queries, nil
@@ -200,7 +306,7 @@ func (r *MetricsRepository) GetProfilingStatus(ctx context.Context, dbName strin
 		SlowMs   int `bson:"slowms"`
 	}
 
-	err := r.client.Database(dbName).RunCommand(ctx, bson.D{{"profile", -1}}).Decode(&result)
+	err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, bson.D{{Key: "profile", Value: -1}}).Decode(&result)
 	if err != nil {
 		return 0, 0, fmt.Errorf("get profiling status: %w", err)
 	}
@@ -217,7 +323,7 @@ func (r *MetricsRepository) SetProfilingLevel(ctx context.Context, dbName string
 	}
 
 	var result bson.M
-	err := r.client.Database(dbName).RunCommand(ctx, cmd).Decode(&result)
+	err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Decode(&result)
 	if err != nil {
 		return fmt.Errorf("set profiling level: %w", err)
 	}
@@ -225,26 +331,3 @@ func (r *MetricsRepository) SetProfilingLevel(ctx context.Context, dbName string
 	return nil
 }
 
-func (r *MetricsRepository) GetTruePaidSubscribers(ctx context.Context, dbName string) (int64, error) {
-	excludedEmails := []string{
-		"daleneumeister@gmail.com",
-		"testflight@gmail.com",
-		"admin@gmail.com",
-		"brandonbaldwin1987@gmail.com",
-		"carterperez4433@gmail.com",
-	}
-
-	filter := bson.D{
-		{Key: "subscriptionStatus", Value: "active"},
-		{Key: "stripeSubscriptionId", Value: bson.D{{Key: "$regex", Value: "^sub_"}}},
-		{Key: "stripeCustomerId", Value: bson.D{{Key: "$regex", Value: "^cus_"}}},
-		{Key: "email", Value: bson.D{{Key: "$nin", Value: excludedEmails}}},
-		{Key: "tags", Value: bson.D{{Key: "$ne", Value: "PROMO"}}},
-	}
-
-	count, err := r.client.Database(dbName).Collection("mainusers").CountDocuments(ctx, filter)
-	if err != nil {
-		return 0, fmt.Errorf("count paid subscribers: %w", err)
-	}
-	return count, nil
-}
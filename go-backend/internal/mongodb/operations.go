@@ -0,0 +1,180 @@
+/*
+AngelaMos | 2026
+operations.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrOperationNotFound = errors.New("operation not found")
+
+type OperationsRepository struct {
+	client *Client
+}
+
+func NewOperationsRepository(client *Client) *OperationsRepository {
+	return &OperationsRepository{client: client}
+}
+
+type OperationFilter struct {
+	Namespace       string
+	MinRunningMs    int64
+	OpType          string
+	Client          string
+	IncludeInactive bool
+}
+
+type OperationDetail struct {
+	OpID             int64             `bson:"opid"`
+	Active           bool              `bson:"active"`
+	Op               string            `bson:"op"`
+	Namespace        string            `bson:"ns"`
+	Description      string            `bson:"desc"`
+	SecsRunning      int64             `bson:"secs_running"`
+	MicrosecsRunning int64             `bson:"microsecs_running"`
+	Client           string            `bson:"client"`
+	AppName          string            `bson:"appName"`
+	PlanSummary      string            `bson:"planSummary"`
+	NumYields        int64             `bson:"numYields"`
+	WaitingForLock   bool              `bson:"waitingForLock"`
+	Locks            map[string]string `bson:"locks"`
+	LockStats        bson.Raw          `bson:"lockStats"`
+	Command          bson.Raw          `bson:"command"`
+	OriginatingCmd   bson.Raw          `bson:"originatingCommand"`
+	EffectiveUsers   []struct {
+		User string `bson:"user"`
+		DB   string `bson:"db"`
+	} `bson:"effectiveUsers"`
+}
+
+func (r *OperationsRepository) ListOperations(ctx context.Context, filter OperationFilter) ([]OperationDetail, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{
+			{Key: "allUsers", Value: true},
+			{Key: "idleConnections", Value: false},
+		}}},
+	}
+
+	match := buildOperationMatch(filter)
+	if len(match) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "microsecs_running", Value: -1}}}})
+
+	return r.runCurrentOp(ctx, pipeline)
+}
+
+func (r *OperationsRepository) GetOperation(ctx context.Context, opID int64) (*OperationDetail, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{
+			{Key: "allUsers", Value: true},
+			{Key: "idleConnections", Value: true},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "opid", Value: opID}}}},
+		{{Key: "$limit", Value: 1}},
+	}
+
+	ops, err := r.runCurrentOp(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, ErrOperationNotFound
+	}
+
+	return &ops[0], nil
+}
+
+func (r *OperationsRepository) KillOperation(ctx context.Context, opID int64) error {
+	cmd := bson.D{
+		{Key: "killOp", Value: 1},
+		{Key: "op", Value: opID},
+	}
+
+	var result bson.M
+	if err := r.client.forContext(ctx).Database("admin").RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return fmt.Errorf("killOp command: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OperationsRepository) runCurrentOp(ctx context.Context, pipeline mongo.Pipeline) ([]OperationDetail, error) {
+	cursor, err := r.client.forContext(ctx).Database("admin").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("currentOp aggregate: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var ops []OperationDetail
+	for cursor.Next(ctx) {
+		var op OperationDetail
+		if err := cursor.Decode(&op); err != nil {
+			return nil, fmt.Errorf("decode currentOp: %w", err)
+		}
+		ops = append(ops, op)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("iterate currentOp: %w", err)
+	}
+
+	return ops, nil
+}
+
+func buildOperationMatch(filter OperationFilter) bson.D {
+	match := bson.D{}
+
+	if !filter.IncludeInactive {
+		match = append(match, bson.E{Key: "active", Value: true})
+	}
+
+	if filter.Namespace != "" {
+		if strings.Contains(filter.Namespace, ".") {
+			match = append(match, bson.E{Key: "ns", Value: filter.Namespace})
+		} else {
+			match = append(match, bson.E{Key: "ns", Value: bson.D{
+				{Key: "$regex", Value: "^" + regexp.QuoteMeta(filter.Namespace) + `\.`},
+			}})
+		}
+	}
+
+	if filter.MinRunningMs > 0 {
+		match = append(match, bson.E{Key: "microsecs_running", Value: bson.D{
+			{Key: "$gte", Value: filter.MinRunningMs * 1000},
+		}})
+	}
+
+	if filter.OpType != "" {
+		match = append(match, bson.E{Key: "op", Value: filter.OpType})
+	}
+
+	if filter.Client != "" {
+		match = append(match, bson.E{Key: "client", Value: bson.D{
+			{Key: "$regex", Value: "^" + regexp.QuoteMeta(filter.Client)},
+		}})
+	}
+
+	return match
+}
+
+func SplitNamespace(namespace string) (string, string) {
+	dbName, collName, _ := strings.Cut(namespace, ".")
+	return dbName, collName
+}
+
+func DatabaseFromNamespace(namespace string) string {
+	dbName, _ := SplitNamespace(namespace)
+	return dbName
+}
@@ -0,0 +1,116 @@
+/*
+AngelaMos | 2026
+profiler.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ProfileEntry struct {
+	SlowQuery `bson:",inline"`
+	QueryHash string   `bson:"queryHash"`
+	AppName   string   `bson:"appName"`
+	Raw       bson.Raw `bson:"-"`
+}
+
+type ProfileTail struct {
+	Since     time.Time
+	Exclusive bool
+	Until     time.Time
+	Limit     int
+}
+
+func (r *MetricsRepository) TailProfile(ctx context.Context, dbName string, tail ProfileTail) ([]ProfileEntry, int, error) {
+	ts := bson.D{}
+	if !tail.Since.IsZero() {
+		op := "$gte"
+		if tail.Exclusive {
+			op = "$gt"
+		}
+		ts = append(ts, bson.E{Key: op, Value: tail.Since})
+	}
+	if !tail.Until.IsZero() {
+		ts = append(ts, bson.E{Key: "$lte", Value: tail.Until})
+	}
+
+	filter := bson.D{}
+	if len(ts) > 0 {
+		filter = append(filter, bson.E{Key: "ts", Value: ts})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "ts", Value: 1}})
+	if tail.Limit > 0 {
+		opts.SetLimit(int64(tail.Limit))
+	}
+
+	cursor, err := r.client.forContext(ctx).Database(dbName).Collection("system.profile").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tail system.profile: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []ProfileEntry
+	skipped := 0
+	for cursor.Next(ctx) {
+		var entry ProfileEntry
+		if err := cursor.Decode(&entry); err != nil {
+			skipped++
+			continue
+		}
+		entry.Raw = append(bson.Raw(nil), cursor.Current...)
+		entries = append(entries, entry)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, skipped, fmt.Errorf("iterate system.profile: %w", err)
+	}
+
+	return entries, skipped, nil
+}
+
+type ProfilingSettings struct {
+	Level      int      `bson:"was"`
+	SlowMs     int      `bson:"slowms"`
+	SampleRate float64  `bson:"sampleRate"`
+	Filter     bson.Raw `bson:"filter"`
+}
+
+func (r *MetricsRepository) GetProfilingSettings(ctx context.Context, dbName string) (*ProfilingSettings, error) {
+	var settings ProfilingSettings
+	err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, bson.D{{Key: "profile", Value: -1}}).Decode(&settings)
+	if err != nil {
+		return nil, fmt.Errorf("get profiling settings: %w", err)
+	}
+	return &settings, nil
+}
+
+func (r *MetricsRepository) ApplyProfilingSettings(ctx context.Context, dbName string, settings ProfilingSettings, unsetFilter bool) (*ProfilingSettings, error) {
+	cmd := bson.D{{Key: "profile", Value: settings.Level}}
+	if settings.SlowMs > 0 {
+		cmd = append(cmd, bson.E{Key: "slowms", Value: settings.SlowMs})
+	}
+	if settings.SampleRate > 0 {
+		cmd = append(cmd, bson.E{Key: "sampleRate", Value: settings.SampleRate})
+	}
+	switch {
+	case len(settings.Filter) > 0:
+		cmd = append(cmd, bson.E{Key: "filter", Value: settings.Filter})
+	case unsetFilter:
+		cmd = append(cmd, bson.E{Key: "filter", Value: "unset"})
+	}
+
+	var previous ProfilingSettings
+	err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Decode(&previous)
+	if err != nil {
+		return nil, fmt.Errorf("apply profiling settings: %w", err)
+	}
+	return &previous, nil
+}
@@ -0,0 +1,317 @@
+/*
+AngelaMos | 2026
+registry.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+)
+
+var (
+	ErrClusterNotFound = errors.New("cluster not found")
+	ErrClusterExists   = errors.New("cluster already registered")
+	ErrDefaultCluster  = errors.New("default cluster cannot be removed")
+)
+
+const (
+	ClusterSourceConfig = "config"
+	ClusterSourceStored = "sqlite"
+)
+
+type Registry struct {
+	clusters    map[string]*registeredCluster
+	defaultName string
+	mu          sync.RWMutex
+	logger      *slog.Logger
+}
+
+type registeredCluster struct {
+	cfg    config.MongoConfig
+	source string
+	client *Client
+	health ClusterHealth
+}
+
+type ClusterHealth struct {
+	Healthy   bool      `json:"healthy"`
+	Latency   string    `json:"latency,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+	Message   string    `json:"message,omitempty"`
+}
+
+type ClusterStatus struct {
+	Name        string        `json:"name"`
+	URI         string        `json:"uri"`
+	Database    string        `json:"database"`
+	MaxPoolSize uint64        `json:"max_pool_size"`
+	MinPoolSize uint64        `json:"min_pool_size"`
+	Source      string        `json:"source"`
+	Default     bool          `json:"default"`
+	Health      ClusterHealth `json:"health"`
+}
+
+func NewRegistry(defaultClient *Client, cfg config.MongoConfig, logger *slog.Logger) *Registry {
+	return &Registry{
+		clusters: map[string]*registeredCluster{
+			cfg.Name: {
+				cfg:    cfg,
+				source: ClusterSourceConfig,
+				client: defaultClient,
+				health: ClusterHealth{Healthy: true, CheckedAt: time.Now()},
+			},
+		},
+		defaultName: cfg.Name,
+		logger:      logger,
+	}
+}
+
+func (r *Registry) Register(ctx context.Context, cfg config.MongoConfig, source string) error {
+	r.mu.RLock()
+	_, exists := r.clusters[cfg.Name]
+	r.mu.RUnlock()
+	if exists {
+		return ErrClusterExists
+	}
+
+	client, err := NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect cluster %s: %w", cfg.Name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clusters[cfg.Name]; exists {
+		_ = client.Close(ctx)
+		return ErrClusterExists
+	}
+
+	r.clusters[cfg.Name] = &registeredCluster{
+		cfg:    cfg,
+		source: source,
+		client: client,
+		health: ClusterHealth{Healthy: true, CheckedAt: time.Now()},
+	}
+
+	r.logger.Info("cluster registered",
+		"cluster", cfg.Name,
+		"database", cfg.Database,
+		"source", source,
+	)
+	return nil
+}
+
+func (r *Registry) Remove(ctx context.Context, name string) error {
+	if name == r.defaultName {
+		return ErrDefaultCluster
+	}
+
+	r.mu.Lock()
+	cluster, ok := r.clusters[name]
+	if ok {
+		delete(r.clusters, name)
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return ErrClusterNotFound
+	}
+
+	if err := cluster.client.Close(ctx); err != nil {
+		return fmt.Errorf("close cluster %s: %w", name, err)
+	}
+
+	r.logger.Info("cluster removed", "cluster", name)
+	return nil
+}
+
+func (r *Registry) Get(name string) (*Client, error) {
+	if name == "" {
+		name = r.defaultName
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cluster, ok := r.clusters[name]
+	if !ok {
+		return nil, ErrClusterNotFound
+	}
+	return cluster.client, nil
+}
+
+func (r *Registry) Default() *Client {
+	client, _ := r.Get("")
+	return client
+}
+
+func (r *Registry) Source(name string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cluster, ok := r.clusters[name]
+	if !ok {
+		return "", ErrClusterNotFound
+	}
+	return cluster.source, nil
+}
+
+func (r *Registry) Status(name string) (*ClusterStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cluster, ok := r.clusters[name]
+	if !ok {
+		return nil, ErrClusterNotFound
+	}
+
+	status := r.toStatus(name, cluster)
+	return &status, nil
+}
+
+func (r *Registry) List() []ClusterStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]ClusterStatus, 0, len(r.clusters))
+	for name, cluster := range r.clusters {
+		statuses = append(statuses, r.toStatus(name, cluster))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Default != statuses[j].Default {
+			return statuses[i].Default
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+func (r *Registry) CheckHealth(ctx context.Context, name string) (*ClusterHealth, error) {
+	client, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = r.defaultName
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	pingErr := client.Ping(pingCtx)
+
+	health := ClusterHealth{
+		Healthy:   pingErr == nil,
+		Latency:   time.Since(start).String(),
+		CheckedAt: time.Now(),
+	}
+	if pingErr != nil {
+		health.Message = "ping failed: " + pingErr.Error()
+	}
+
+	r.mu.Lock()
+	if cluster, ok := r.clusters[name]; ok {
+		cluster.health = health
+	}
+	r.mu.Unlock()
+
+	return &health, nil
+}
+
+func (r *Registry) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, name := range r.names() {
+					health, err := r.CheckHealth(ctx, name)
+					if err != nil || health.Healthy {
+						continue
+					}
+					r.logger.Warn("cluster health check failed", "cluster", name)
+				}
+			}
+		}
+	}()
+}
+
+func (r *Registry) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for name, cluster := range r.clusters {
+		if err := cluster.client.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("close cluster %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.clusters))
+	for name := range r.clusters {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r *Registry) toStatus(name string, cluster *registeredCluster) ClusterStatus {
+	return ClusterStatus{
+		Name:        name,
+		URI:         RedactURI(cluster.cfg.URI),
+		Database:    cluster.cfg.Database,
+		MaxPoolSize: cluster.cfg.MaxPoolSize,
+		MinPoolSize: cluster.cfg.MinPoolSize,
+		Source:      cluster.source,
+		Default:     name == r.defaultName,
+		Health:      cluster.health,
+	}
+}
+
+func RedactURI(uri string) string {
+	schemeEnd := strings.Index(uri, "://")
+	if schemeEnd < 0 {
+		return uri
+	}
+
+	rest := uri[schemeEnd+3:]
+	authorityEnd := strings.IndexAny(rest, "/?")
+	if authorityEnd < 0 {
+		authorityEnd = len(rest)
+	}
+
+	at := strings.LastIndex(rest[:authorityEnd], "@")
+	if at < 0 {
+		return uri
+	}
+
+	userInfo := rest[:at]
+	if colon := strings.Index(userInfo, ":"); colon >= 0 {
+		userInfo = userInfo[:colon] + ":xxxxx"
+	}
+
+	return uri[:schemeEnd+3] + userInfo + rest[at:]
+}
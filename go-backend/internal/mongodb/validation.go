@@ -0,0 +1,78 @@
+/*
+AngelaMos | 2026
+validation.go
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var ErrCollectionNotFound = errors.New("collection not found")
+
+type ValidatorInfo struct {
+	Validator        bson.Raw
+	ValidationLevel  string
+	ValidationAction string
+}
+
+func (r *CollectionsRepository) GetValidator(ctx context.Context, dbName, collName string) (*ValidatorInfo, error) {
+	db := r.client.forContext(ctx).client.Database(dbName)
+
+	cursor, err := db.ListCollections(ctx, bson.D{{Key: "name", Value: collName}})
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return nil, fmt.Errorf("list collections: %w", err)
+		}
+		return nil, ErrCollectionNotFound
+	}
+
+	info := &ValidatorInfo{
+		ValidationLevel:  "strict",
+		ValidationAction: "error",
+	}
+
+	opts, ok := cursor.Current.Lookup("options").DocumentOK()
+	if !ok {
+		return info, nil
+	}
+	if validator, ok := opts.Lookup("validator").DocumentOK(); ok {
+		info.Validator = append(bson.Raw(nil), validator...)
+	}
+	if level, ok := opts.Lookup("validationLevel").StringValueOK(); ok {
+		info.ValidationLevel = level
+	}
+	if action, ok := opts.Lookup("validationAction").StringValueOK(); ok {
+		info.ValidationAction = action
+	}
+
+	return info, nil
+}
+
+func (r *CollectionsRepository) SetValidator(ctx context.Context, dbName, collName string, validator bson.D, level, action string) error {
+	if validator == nil {
+		validator = bson.D{}
+	}
+
+	cmd := bson.D{
+		{Key: "collMod", Value: collName},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: level},
+		{Key: "validationAction", Value: action},
+	}
+
+	if err := r.client.forContext(ctx).Database(dbName).RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("collMod validator: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package operations
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const confirmTokenTTL = 2 * time.Minute
+
+type operationsRepository interface {
+	ListOperations(ctx context.Context, filter mongodb.OperationFilter) ([]mongodb.OperationDetail, error)
+	GetOperation(ctx context.Context, opID int64) (*mongodb.OperationDetail, error)
+	KillOperation(ctx context.Context, opID int64) error
+}
+
+type auditRepository interface {
+	Create(ctx context.Context, e *sqlite.AuditEntry) error
+}
+
+type Service struct {
+	repo   operationsRepository
+	audit  auditRepository
+	tokens map[string]confirmToken
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+type confirmToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewService(repo operationsRepository, audit auditRepository, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		audit:  audit,
+		tokens: make(map[string]confirmToken),
+		logger: logger,
+	}
+}
+
+type Operation struct {
+	OpID          int64   `json:"opid"`
+	Active        bool    `json:"active"`
+	Type          string  `json:"type"`
+	Namespace     string  `json:"namespace"`
+	Description   string  `json:"description"`
+	MillisRunning float64 `json:"millis_running"`
+	Client        string  `json:"client"`
+	AppName       string  `json:"app_name,omitempty"`
+	PlanSummary   string  `json:"plan_summary,omitempty"`
+	WaitingLock   bool    `json:"waiting_for_lock"`
+}
+
+type OperationDetail struct {
+	Operation
+	NumYields          int64             `json:"num_yields"`
+	Users              []string          `json:"users,omitempty"`
+	Locks              map[string]string `json:"locks,omitempty"`
+	LockStats          json.RawMessage   `json:"lock_stats,omitempty"`
+	Command            json.RawMessage   `json:"command,omitempty"`
+	OriginatingCommand json.RawMessage   `json:"originating_command,omitempty"`
+	ConfirmToken       string            `json:"confirm_token"`
+	ConfirmExpiresAt   time.Time         `json:"confirm_expires_at"`
+}
+
+type Actor struct {
+	RequestID  string
+	RemoteAddr string
+}
+
+func (s *Service) ListOperations(ctx context.Context, filter mongodb.OperationFilter) ([]Operation, error) {
+	ops, err := s.repo.ListOperations(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list operations: %w", err)
+	}
+
+	result := make([]Operation, 0, len(ops))
+	for i := range ops {
+		result = append(result, toOperation(&ops[i]))
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetOperation(ctx context.Context, opID int64) (*OperationDetail, error) {
+	op, err := s.repo.GetOperation(ctx, opID)
+	if errors.Is(err, mongodb.ErrOperationNotFound) {
+		return nil, core.NotFoundError("operation")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get operation: %w", err)
+	}
+
+	token, err := s.issueToken(tokenKey(ctx, opID))
+	if err != nil {
+		return nil, fmt.Errorf("issue confirm token: %w", err)
+	}
+
+	users := make([]string, 0, len(op.EffectiveUsers))
+	for _, u := range op.EffectiveUsers {
+		users = append(users, u.User+"@"+u.DB)
+	}
+
+	return &OperationDetail{
+		Operation:          toOperation(op),
+		NumYields:          op.NumYields,
+		Users:              users,
+		Locks:              op.Locks,
+		LockStats:          mongodb.EncodeExtJSON(op.LockStats, mongodb.ExtJSONRelaxed),
+		Command:            mongodb.EncodeExtJSON(op.Command, mongodb.ExtJSONRelaxed),
+		OriginatingCommand: mongodb.EncodeExtJSON(op.OriginatingCmd, mongodb.ExtJSONRelaxed),
+		ConfirmToken:       token.value,
+		ConfirmExpiresAt:   token.expiresAt,
+	}, nil
+}
+
+func (s *Service) KillOperation(ctx context.Context, opID int64, token string, actor Actor) error {
+	if !s.consumeToken(tokenKey(ctx, opID), token) {
+		return core.ForbiddenError("invalid or expired confirmation token")
+	}
+
+	op, err := s.repo.GetOperation(ctx, opID)
+	if errors.Is(err, mongodb.ErrOperationNotFound) {
+		return core.NotFoundError("operation")
+	}
+	if err != nil {
+		return fmt.Errorf("get operation: %w", err)
+	}
+
+	killErr := s.repo.KillOperation(ctx, opID)
+
+	s.recordKill(ctx, op, actor, killErr)
+
+	if killErr != nil {
+		return fmt.Errorf("kill operation: %w", killErr)
+	}
+
+	s.logger.Info("operation killed",
+		"opid", opID,
+		"namespace", op.Namespace,
+		"request_id", actor.RequestID,
+	)
+	return nil
+}
+
+func (s *Service) recordKill(ctx context.Context, op *mongodb.OperationDetail, actor Actor, killErr error) {
+	details, _ := json.Marshal(map[string]any{
+		"op":           op.Op,
+		"client":       op.Client,
+		"app_name":     op.AppName,
+		"secs_running": op.SecsRunning,
+		"plan_summary": op.PlanSummary,
+		"command":      mongodb.EncodeExtJSON(op.Command, mongodb.ExtJSONRelaxed),
+	})
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       "operation.kill",
+		Resource:     "operation",
+		ResourceID:   strconv.FormatInt(op.OpID, 10),
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: mongodb.DatabaseFromNamespace(op.Namespace),
+		Details:      sql.NullString{String: string(details), Valid: len(details) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if killErr != nil {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: killErr.Error(), Valid: true}
+	}
+
+	if err := s.audit.Create(ctx, entry); err != nil {
+		s.logger.Error("failed to write audit entry", "action", entry.Action, "error", err)
+	}
+}
+
+func (s *Service) issueToken(key string) (confirmToken, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return confirmToken{}, err
+	}
+
+	token := confirmToken{
+		value:     hex.EncodeToString(buf),
+		expiresAt: time.Now().Add(confirmTokenTTL),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range s.tokens {
+		if now.After(t.expiresAt) {
+			delete(s.tokens, k)
+		}
+	}
+	s.tokens[key] = token
+
+	return token, nil
+}
+
+func (s *Service) consumeToken(key, value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	if !ok || value == "" {
+		return false
+	}
+	if time.Now().After(token.expiresAt) {
+		delete(s.tokens, key)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(token.value), []byte(value)) != 1 {
+		return false
+	}
+
+	delete(s.tokens, key)
+	return true
+}
+
+func tokenKey(ctx context.Context, opID int64) string {
+	cluster := ""
+	if client, ok := mongodb.ClientFromContext(ctx); ok {
+		cluster = client.Name()
+	}
+	return cluster + "/" + strconv.FormatInt(opID, 10)
+}
+
+func toOperation(op *mongodb.OperationDetail) Operation {
+	return Operation{
+		OpID:          op.OpID,
+		Active:        op.Active,
+		Type:          op.Op,
+		Namespace:     op.Namespace,
+		Description:   op.Description,
+		MillisRunning: float64(op.MicrosecsRunning) / 1000.0,
+		Client:        op.Client,
+		AppName:       op.AppName,
+		PlanSummary:   op.PlanSummary,
+		WaitingLock:   op.WaitingForLock,
+	}
+}
@@ -0,0 +1,423 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package profiler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	SortByTime     = "time"
+	SortByDuration = "duration"
+
+	collectTimeout = 30 * time.Second
+)
+
+var systemDatabases = map[string]bool{
+	"admin":  true,
+	"local":  true,
+	"config": true,
+}
+
+type profileSource interface {
+	ListDatabases(ctx context.Context) ([]string, error)
+	GetProfilingStatus(ctx context.Context, dbName string) (int, int, error)
+	TailProfile(ctx context.Context, dbName string, tail mongodb.ProfileTail) ([]mongodb.ProfileEntry, int, error)
+}
+
+type slowQueryStore interface {
+	Insert(ctx context.Context, q *sqlite.StoredSlowQuery) (bool, error)
+	LatestTimestamp(ctx context.Context, clusterName, dbName string) (time.Time, error)
+	List(ctx context.Context, filter sqlite.SlowQueryFilter) ([]*sqlite.StoredSlowQuery, int, error)
+	Summarize(ctx context.Context, filter sqlite.SlowQueryFilter) ([]*sqlite.SlowQuerySummary, error)
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type clusterLister interface {
+	List() []mongodb.ClusterStatus
+	Get(name string) (*mongodb.Client, error)
+}
+
+type Service struct {
+	source        profileSource
+	store         slowQueryStore
+	clusters      clusterLister
+	interval      time.Duration
+	retentionDays int
+	batchSize     int
+	databases     []string
+	watermarks    map[string]time.Time
+	mu            sync.Mutex
+	logger        *slog.Logger
+}
+
+type SlowQuery struct {
+	ID             int64           `json:"id"`
+	Cluster        string          `json:"cluster"`
+	Database       string          `json:"database"`
+	Namespace      string          `json:"namespace"`
+	Op             string          `json:"op"`
+	Millis         int64           `json:"millis"`
+	PlanSummary    string          `json:"plan_summary"`
+	KeysExamined   int64           `json:"keys_examined"`
+	DocsExamined   int64           `json:"docs_examined"`
+	NReturned      int64           `json:"nreturned"`
+	NumYields      int64           `json:"num_yields"`
+	ResponseLength int64           `json:"response_length"`
+	HasSortStage   bool            `json:"has_sort_stage"`
+	QueryHash      string          `json:"query_hash,omitempty"`
+	Client         string          `json:"client"`
+	User           string          `json:"user"`
+	AppName        string          `json:"app_name,omitempty"`
+	Command        json.RawMessage `json:"command,omitempty"`
+	Timestamp      time.Time       `json:"timestamp"`
+	CollectedAt    time.Time       `json:"collected_at"`
+}
+
+type SearchFilter struct {
+	Cluster     string
+	Database    string
+	Namespace   string
+	Op          string
+	PlanSummary string
+	From        time.Time
+	To          time.Time
+	MinMillis   int64
+	SortBy      string
+	Page        int
+	PageSize    int
+}
+
+func NewService(source profileSource, store slowQueryStore, clusters clusterLister, cfg config.ProfilerConfig, logger *slog.Logger) *Service {
+	return &Service{
+		source:        source,
+		store:         store,
+		clusters:      clusters,
+		interval:      cfg.CollectInterval,
+		retentionDays: cfg.RetentionDays,
+		batchSize:     cfg.BatchSize,
+		databases:     cfg.Databases,
+		watermarks:    make(map[string]time.Time),
+		logger:        logger,
+	}
+}
+
+func (s *Service) Start(ctx context.Context) {
+	go func() {
+		s.CollectAll(ctx)
+		s.prune(ctx)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		pruneEvery := int(time.Hour / s.interval)
+		if pruneEvery < 1 {
+			pruneEvery = 1
+		}
+
+		for tick := 1; ; tick++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.CollectAll(ctx)
+				if tick%pruneEvery == 0 {
+					s.prune(ctx)
+				}
+			}
+		}
+	}()
+}
+
+func (s *Service) CollectAll(ctx context.Context) {
+	for _, cluster := range s.clusters.List() {
+		client, err := s.clusters.Get(cluster.Name)
+		if err != nil {
+			continue
+		}
+
+		clusterCtx := mongodb.WithClient(ctx, client)
+		databases, err := s.profiledDatabases(clusterCtx)
+		if err != nil {
+			s.logger.Warn("failed to list profiled databases", "cluster", cluster.Name, "error", err)
+			continue
+		}
+
+		for _, dbName := range databases {
+			inserted, err := s.collect(clusterCtx, cluster.Name, dbName)
+			if err != nil {
+				s.logger.Warn("failed to collect slow queries", "cluster", cluster.Name, "database", dbName, "error", err)
+				continue
+			}
+			if inserted > 0 {
+				s.logger.Debug("collected slow queries", "cluster", cluster.Name, "database", dbName, "count", inserted)
+			}
+		}
+	}
+}
+
+func (s *Service) Search(ctx context.Context, filter SearchFilter) ([]SlowQuery, int, error) {
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = SortByTime
+	case SortByTime, SortByDuration:
+	default:
+		return nil, 0, core.ValidationError("sort must be time or duration")
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, 0, core.ValidationError("to must not be before from")
+	}
+
+	stored, total, err := s.store.List(ctx, sqlite.SlowQueryFilter{
+		ClusterName:  filter.Cluster,
+		DatabaseName: filter.Database,
+		Namespace:    filter.Namespace,
+		Op:           filter.Op,
+		PlanSummary:  filter.PlanSummary,
+		From:         filter.From,
+		To:           filter.To,
+		MinMillis:    filter.MinMillis,
+		SortBy:       filter.SortBy,
+		Limit:        filter.PageSize,
+		Offset:       (filter.Page - 1) * filter.PageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queries := make([]SlowQuery, 0, len(stored))
+	for _, q := range stored {
+		queries = append(queries, toSlowQuery(q))
+	}
+	return queries, total, nil
+}
+
+func (s *Service) profiledDatabases(ctx context.Context) ([]string, error) {
+	if len(s.databases) > 0 {
+		return s.databases, nil
+	}
+
+	databases, err := s.source.ListDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiled []string
+	for _, dbName := range databases {
+		if systemDatabases[dbName] {
+			continue
+		}
+		level, _, err := s.source.GetProfilingStatus(ctx, dbName)
+		if err != nil || level == 0 {
+			continue
+		}
+		profiled = append(profiled, dbName)
+	}
+	return profiled, nil
+}
+
+func (s *Service) collect(ctx context.Context, clusterName, dbName string) (int, error) {
+	since, err := s.watermark(ctx, clusterName, dbName)
+	if err != nil {
+		return 0, err
+	}
+
+	inserted := 0
+	exclusive := false
+	for {
+		entries, skipped, err := s.tail(ctx, clusterName, dbName, mongodb.ProfileTail{
+			Since:     since,
+			Exclusive: exclusive,
+			Limit:     s.batchSize,
+		})
+		if err != nil {
+			return inserted, err
+		}
+
+		n, next, err := s.storeEntries(ctx, clusterName, dbName, entries, since)
+		inserted += n
+		if err != nil {
+			return inserted, err
+		}
+
+		s.setWatermark(clusterName, dbName, next)
+
+		if len(entries) == 0 || len(entries)+skipped < s.batchSize {
+			return inserted, nil
+		}
+
+		if next.After(since) {
+			since = next
+			exclusive = false
+			continue
+		}
+
+		boundary, _, err := s.tail(ctx, clusterName, dbName, mongodb.ProfileTail{Since: since, Until: since})
+		if err != nil {
+			return inserted, err
+		}
+		n, _, err = s.storeEntries(ctx, clusterName, dbName, boundary, since)
+		inserted += n
+		if err != nil {
+			return inserted, err
+		}
+		exclusive = true
+	}
+}
+
+func (s *Service) tail(ctx context.Context, clusterName, dbName string, tail mongodb.ProfileTail) ([]mongodb.ProfileEntry, int, error) {
+	tailCtx, cancel := context.WithTimeout(ctx, collectTimeout)
+	defer cancel()
+
+	entries, skipped, err := s.source.TailProfile(tailCtx, dbName, tail)
+	if skipped > 0 {
+		s.logger.Warn("skipped undecodable system.profile entries", "cluster", clusterName, "database", dbName, "skipped", skipped)
+	}
+	return entries, skipped, err
+}
+
+func (s *Service) storeEntries(ctx context.Context, clusterName, dbName string, entries []mongodb.ProfileEntry, since time.Time) (int, time.Time, error) {
+	inserted := 0
+	next := since
+	collectedAt := time.Now()
+	for _, entry := range entries {
+		ok, err := s.store.Insert(ctx, toStored(clusterName, dbName, entry, collectedAt))
+		if err != nil {
+			return inserted, next, err
+		}
+		if ok {
+			inserted++
+		}
+		if entry.Timestamp.After(next) {
+			next = entry.Timestamp
+		}
+	}
+	return inserted, next, nil
+}
+
+func (s *Service) watermark(ctx context.Context, clusterName, dbName string) (time.Time, error) {
+	key := clusterName + "/" + dbName
+
+	s.mu.Lock()
+	ts, ok := s.watermarks[key]
+	s.mu.Unlock()
+	if ok {
+		return ts, nil
+	}
+
+	ts, err := s.store.LatestTimestamp(ctx, clusterName, dbName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.setWatermark(clusterName, dbName, ts)
+	return ts, nil
+}
+
+func (s *Service) setWatermark(clusterName, dbName string, ts time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watermarks[clusterName+"/"+dbName] = ts
+}
+
+func (s *Service) prune(ctx context.Context) {
+	if s.retentionDays <= 0 {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+	if _, err := s.store.DeleteBefore(ctx, cutoff); err != nil {
+		s.logger.Error("failed to prune slow queries", "error", err)
+	}
+}
+
+func toStored(clusterName, dbName string, entry mongodb.ProfileEntry, collectedAt time.Time) *sqlite.StoredSlowQuery {
+	hash := sha256.New()
+	hash.Write([]byte(clusterName))
+	hash.Write([]byte{0})
+	hash.Write([]byte(dbName))
+	hash.Write([]byte{0})
+	hash.Write(entry.Raw)
+
+	return &sqlite.StoredSlowQuery{
+		EntryHash:      hex.EncodeToString(hash.Sum(nil)),
+		ClusterName:    clusterName,
+		DatabaseName:   dbName,
+		Namespace:      entry.Namespace,
+		Op:             entry.Op,
+		Millis:         int64(entry.MillisRuntime),
+		PlanSummary:    entry.PlanSummary,
+		KeysExamined:   entry.KeysExamined,
+		DocsExamined:   entry.DocsExamined,
+		NReturned:      entry.NReturned,
+		NumYields:      int64(entry.NumYields),
+		ResponseLength: int64(entry.ResponseLen),
+		HasSortStage:   entry.HasSortStage,
+		QueryHash:      entry.QueryHash,
+		Client:         entry.Client,
+		User:           entry.User,
+		AppName:        entry.AppName,
+		Command:        commandJSON(entry),
+		Timestamp:      entry.Timestamp,
+		CollectedAt:    collectedAt,
+	}
+}
+
+func commandJSON(entry mongodb.ProfileEntry) string {
+	var raw bson.Raw
+	switch {
+	case entry.Op == "getmore" && len(entry.Originating) > 0:
+		raw = entry.Originating
+	case len(entry.Command) > 0:
+		raw = entry.Command
+	case len(entry.Query) > 0:
+		raw = entry.Query
+	default:
+		return ""
+	}
+	return string(mongodb.EncodeExtJSON(raw, mongodb.ExtJSONRelaxed))
+}
+
+func toSlowQuery(q *sqlite.StoredSlowQuery) SlowQuery {
+	out := SlowQuery{
+		ID:             q.ID,
+		Cluster:        q.ClusterName,
+		Database:       q.DatabaseName,
+		Namespace:      q.Namespace,
+		Op:             q.Op,
+		Millis:         q.Millis,
+		PlanSummary:    q.PlanSummary,
+		KeysExamined:   q.KeysExamined,
+		DocsExamined:   q.DocsExamined,
+		NReturned:      q.NReturned,
+		NumYields:      q.NumYields,
+		ResponseLength: q.ResponseLength,
+		HasSortStage:   q.HasSortStage,
+		QueryHash:      q.QueryHash,
+		Client:         q.Client,
+		User:           q.User,
+		AppName:        q.AppName,
+		Timestamp:      q.Timestamp,
+		CollectedAt:    q.CollectedAt,
+	}
+	if q.Command != "" {
+		out.Command = json.RawMessage(q.Command)
+	}
+	return out
+}
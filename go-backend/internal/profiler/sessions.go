@@ -0,0 +1,521 @@
+/*
+AngelaMos | 2026
+sessions.go
+*/
+
+package profiler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	SessionActive    = "active"
+	SessionCompleted = "completed"
+	SessionStopped   = "stopped"
+	SessionFailed    = "failed"
+
+	minSessionDuration = 10 * time.Second
+	maxSessionDuration = 24 * time.Hour
+	sessionTimeout     = 30 * time.Second
+	reportSlowest      = 20
+	revertRetryBase    = 15 * time.Second
+	revertRetryMax     = 10 * time.Minute
+)
+
+type profilingController interface {
+	ApplyProfilingSettings(ctx context.Context, dbName string, settings mongodb.ProfilingSettings, unsetFilter bool) (*mongodb.ProfilingSettings, error)
+}
+
+type sessionRepository interface {
+	Create(ctx context.Context, s *sqlite.ProfilerSession) error
+	Finish(ctx context.Context, id, status string, endedAt time.Time, errorMsg string) error
+	GetByID(ctx context.Context, id string) (*sqlite.ProfilerSession, error)
+	ListRecent(ctx context.Context, limit int) ([]*sqlite.ProfilerSession, error)
+	ListByStatus(ctx context.Context, status string) ([]*sqlite.ProfilerSession, error)
+}
+
+type SessionManager struct {
+	controller profilingController
+	repo       sessionRepository
+	collector  *Service
+	timers     map[string]*time.Timer
+	attempts   map[string]int
+	locks      map[string]*sync.Mutex
+	mu         sync.Mutex
+	logger     *slog.Logger
+}
+
+type Session struct {
+	ID        string          `json:"id"`
+	Cluster   string          `json:"cluster"`
+	Database  string          `json:"database"`
+	Settings  SessionSettings `json:"settings"`
+	Previous  SessionSettings `json:"previous"`
+	Status    string          `json:"status"`
+	StartedAt time.Time       `json:"started_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	EndedAt   *time.Time      `json:"ended_at,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type SessionSettings struct {
+	Level      int             `json:"level"`
+	SlowMs     int             `json:"slow_ms"`
+	SampleRate float64         `json:"sample_rate"`
+	Filter     json.RawMessage `json:"filter,omitempty"`
+}
+
+type SessionInput struct {
+	Database   string
+	Level      int
+	SlowMs     int
+	SampleRate float64
+	Filter     json.RawMessage
+	Duration   time.Duration
+}
+
+type SessionReport struct {
+	Session     Session            `json:"session"`
+	QueryCount  int                `json:"query_count"`
+	TotalMillis int64              `json:"total_millis"`
+	Namespaces  []NamespaceSummary `json:"namespaces"`
+	Slowest     []SlowQuery        `json:"slowest"`
+}
+
+type NamespaceSummary struct {
+	Namespace   string  `json:"namespace"`
+	Op          string  `json:"op"`
+	Count       int     `json:"count"`
+	TotalMillis int64   `json:"total_millis"`
+	MaxMillis   int64   `json:"max_millis"`
+	AvgMillis   float64 `json:"avg_millis"`
+}
+
+func NewSessionManager(controller profilingController, repo sessionRepository, collector *Service, logger *slog.Logger) *SessionManager {
+	return &SessionManager{
+		controller: controller,
+		repo:       repo,
+		collector:  collector,
+		timers:     make(map[string]*time.Timer),
+		attempts:   make(map[string]int),
+		locks:      make(map[string]*sync.Mutex),
+		logger:     logger,
+	}
+}
+
+func (m *SessionManager) Resume(ctx context.Context) error {
+	sessions, err := m.repo.ListByStatus(ctx, SessionActive)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		remaining := time.Until(s.ExpiresAt)
+		if remaining <= 0 {
+			m.end(s.ID, SessionCompleted)
+			continue
+		}
+		m.schedule(s.ID, remaining, SessionCompleted)
+		m.logger.Info("resumed profiler session", "id", s.ID, "database", s.DatabaseName, "remaining", remaining)
+	}
+	return nil
+}
+
+func (m *SessionManager) Start(ctx context.Context, input SessionInput) (*Session, error) {
+	if input.Database == "" {
+		return nil, core.ValidationError("database is required")
+	}
+	if input.Level != 1 && input.Level != 2 {
+		return nil, core.ValidationError("level must be 1 or 2")
+	}
+	if input.SampleRate < 0 || input.SampleRate > 1 {
+		return nil, core.ValidationError("sample_rate must be between 0 and 1")
+	}
+	if input.Duration < minSessionDuration || input.Duration > maxSessionDuration {
+		return nil, core.ValidationError(fmt.Sprintf("duration must be between %s and %s", minSessionDuration, maxSessionDuration))
+	}
+
+	var filter bson.Raw
+	if len(input.Filter) > 0 && string(input.Filter) != "null" {
+		doc, err := mongodb.ParseExtJSONDocument(input.Filter)
+		if err != nil {
+			return nil, core.ValidationError("invalid filter: " + err.Error())
+		}
+		filter, err = bson.Marshal(doc)
+		if err != nil {
+			return nil, core.ValidationError("invalid filter: " + err.Error())
+		}
+	}
+
+	clusterName := ""
+	if client, ok := mongodb.ClientFromContext(ctx); ok {
+		clusterName = client.Name()
+	}
+
+	unlock := m.lockDatabase(clusterName, input.Database)
+	defer unlock()
+
+	active, err := m.repo.ListByStatus(ctx, SessionActive)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range active {
+		if s.ClusterName == clusterName && s.DatabaseName == input.Database {
+			return nil, core.NewAppError(
+				core.ErrConflict,
+				fmt.Sprintf("profiler session %s is already active on %s", s.ID, input.Database),
+				http.StatusConflict,
+				"CONFLICT",
+			)
+		}
+	}
+
+	previous, err := m.controller.ApplyProfilingSettings(ctx, input.Database, mongodb.ProfilingSettings{
+		Level:      input.Level,
+		SlowMs:     input.SlowMs,
+		SampleRate: input.SampleRate,
+		Filter:     filter,
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("enable profiling: %w", err)
+	}
+
+	now := time.Now()
+	record := &sqlite.ProfilerSession{
+		ID:                 uuid.New().String(),
+		ClusterName:        clusterName,
+		DatabaseName:       input.Database,
+		Level:              input.Level,
+		SlowMs:             input.SlowMs,
+		SampleRate:         input.SampleRate,
+		Filter:             canonicalJSON(filter),
+		PreviousLevel:      previous.Level,
+		PreviousSlowMs:     previous.SlowMs,
+		PreviousSampleRate: previous.SampleRate,
+		PreviousFilter:     canonicalJSON(previous.Filter),
+		Status:             SessionActive,
+		StartedAt:          now,
+		ExpiresAt:          now.Add(input.Duration),
+	}
+
+	if err := m.repo.Create(ctx, record); err != nil {
+		if _, revertErr := m.controller.ApplyProfilingSettings(ctx, input.Database, previousSettings(record), len(filter) > 0); revertErr != nil {
+			m.logger.Error("failed to revert profiling after session error", "database", input.Database, "error", revertErr)
+		}
+		return nil, fmt.Errorf("persist profiler session: %w", err)
+	}
+
+	m.schedule(record.ID, input.Duration, SessionCompleted)
+	m.logger.Info("profiler session started",
+		"id", record.ID,
+		"cluster", clusterName,
+		"database", input.Database,
+		"level", input.Level,
+		"duration", input.Duration,
+	)
+
+	session := toSession(record)
+	return &session, nil
+}
+
+func (m *SessionManager) Stop(ctx context.Context, id string) (*Session, error) {
+	record, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, core.NotFoundError("profiler session")
+	}
+	if record.Status != SessionActive {
+		return nil, core.ValidationError("profiler session is not active")
+	}
+
+	if err := m.end(id, SessionStopped); err != nil {
+		return nil, fmt.Errorf("end profiler session: %w", err)
+	}
+	return m.Get(ctx, id)
+}
+
+func (m *SessionManager) Get(ctx context.Context, id string) (*Session, error) {
+	record, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, core.NotFoundError("profiler session")
+	}
+
+	session := toSession(record)
+	return &session, nil
+}
+
+func (m *SessionManager) List(ctx context.Context, limit int) ([]Session, error) {
+	records, err := m.repo.ListRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(records))
+	for _, r := range records {
+		sessions = append(sessions, toSession(r))
+	}
+	return sessions, nil
+}
+
+func (m *SessionManager) Report(ctx context.Context, id string) (*SessionReport, error) {
+	session, err := m.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now()
+	if session.EndedAt != nil {
+		to = *session.EndedAt
+	}
+
+	filter := sqlite.SlowQueryFilter{
+		ClusterName:  session.Cluster,
+		DatabaseName: session.Database,
+		From:         session.StartedAt,
+		To:           to,
+	}
+
+	summaries, err := m.collector.store.Summarize(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SessionReport{
+		Session:    *session,
+		Namespaces: make([]NamespaceSummary, 0, len(summaries)),
+	}
+	for _, s := range summaries {
+		report.QueryCount += s.Count
+		report.TotalMillis += s.TotalMillis
+		report.Namespaces = append(report.Namespaces, NamespaceSummary{
+			Namespace:   s.Namespace,
+			Op:          s.Op,
+			Count:       s.Count,
+			TotalMillis: s.TotalMillis,
+			MaxMillis:   s.MaxMillis,
+			AvgMillis:   s.AvgMillis,
+		})
+	}
+
+	slowest, _, err := m.collector.Search(ctx, SearchFilter{
+		Cluster:  session.Cluster,
+		Database: session.Database,
+		From:     session.StartedAt,
+		To:       to,
+		SortBy:   SortByDuration,
+		Page:     1,
+		PageSize: reportSlowest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Slowest = slowest
+
+	return report, nil
+}
+
+func (m *SessionManager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, timer := range m.timers {
+		timer.Stop()
+		delete(m.timers, id)
+	}
+}
+
+func (m *SessionManager) schedule(id string, after time.Duration, status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if timer, ok := m.timers[id]; ok {
+		timer.Stop()
+	}
+	m.timers[id] = time.AfterFunc(after, func() {
+		_ = m.end(id, status)
+	})
+}
+
+func (m *SessionManager) end(id, status string) error {
+	m.mu.Lock()
+	if timer, ok := m.timers[id]; ok {
+		timer.Stop()
+		delete(m.timers, id)
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+
+	record, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.Status != SessionActive {
+		return nil
+	}
+
+	unlock := m.lockDatabase(record.ClusterName, record.DatabaseName)
+	defer unlock()
+
+	record, err = m.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.Status != SessionActive {
+		return nil
+	}
+
+	if err := m.revert(ctx, record); err != nil {
+		m.retry(record, status, err)
+		return err
+	}
+
+	m.mu.Lock()
+	delete(m.attempts, id)
+	m.mu.Unlock()
+
+	m.finish(ctx, record, status)
+	return nil
+}
+
+func (m *SessionManager) revert(ctx context.Context, record *sqlite.ProfilerSession) error {
+	client, err := m.collector.clusters.Get(record.ClusterName)
+	if err != nil {
+		return fmt.Errorf("resolve cluster: %w", err)
+	}
+	ctx = mongodb.WithClient(ctx, client)
+
+	if _, err := m.collector.collect(ctx, record.ClusterName, record.DatabaseName); err != nil {
+		m.logger.Warn("failed to flush slow queries for profiler session", "id", record.ID, "error", err)
+	}
+
+	_, err = m.controller.ApplyProfilingSettings(ctx, record.DatabaseName, previousSettings(record), record.Filter != "")
+	if err != nil {
+		return fmt.Errorf("revert profiling: %w", err)
+	}
+	return nil
+}
+
+func (m *SessionManager) retry(record *sqlite.ProfilerSession, status string, cause error) {
+	m.mu.Lock()
+	attempt := m.attempts[record.ID]
+	m.attempts[record.ID] = attempt + 1
+	m.mu.Unlock()
+
+	delay := revertRetryMax
+	if attempt < 10 {
+		delay = min(revertRetryBase<<attempt, revertRetryMax)
+	}
+
+	m.logger.Error("failed to restore profiling settings, session stays active",
+		"id", record.ID,
+		"database", record.DatabaseName,
+		"attempt", attempt+1,
+		"retry_in", delay,
+		"error", cause,
+	)
+	m.schedule(record.ID, delay, status)
+}
+
+func (m *SessionManager) lockDatabase(clusterName, dbName string) func() {
+	key := clusterName + "/" + dbName
+
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func (m *SessionManager) finish(ctx context.Context, record *sqlite.ProfilerSession, status string) {
+	m.logger.Info("profiler session ended", "id", record.ID, "database", record.DatabaseName, "status", status)
+
+	if err := m.repo.Finish(ctx, record.ID, status, time.Now(), ""); err != nil {
+		m.logger.Error("failed to record profiler session end", "id", record.ID, "error", err)
+	}
+}
+
+func previousSettings(record *sqlite.ProfilerSession) mongodb.ProfilingSettings {
+	settings := mongodb.ProfilingSettings{
+		Level:      record.PreviousLevel,
+		SlowMs:     record.PreviousSlowMs,
+		SampleRate: record.PreviousSampleRate,
+	}
+	if record.PreviousFilter != "" {
+		if doc, err := mongodb.ParseExtJSONDocument([]byte(record.PreviousFilter)); err == nil {
+			settings.Filter, _ = bson.Marshal(doc)
+		}
+	}
+	return settings
+}
+
+func canonicalJSON(raw bson.Raw) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	out, err := bson.MarshalExtJSON(raw, true, false)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+func toSession(r *sqlite.ProfilerSession) Session {
+	s := Session{
+		ID:       r.ID,
+		Cluster:  r.ClusterName,
+		Database: r.DatabaseName,
+		Settings: SessionSettings{
+			Level:      r.Level,
+			SlowMs:     r.SlowMs,
+			SampleRate: r.SampleRate,
+		},
+		Previous: SessionSettings{
+			Level:      r.PreviousLevel,
+			SlowMs:     r.PreviousSlowMs,
+			SampleRate: r.PreviousSampleRate,
+		},
+		Status:    r.Status,
+		StartedAt: r.StartedAt,
+		ExpiresAt: r.ExpiresAt,
+	}
+	if r.Filter != "" {
+		s.Settings.Filter = json.RawMessage(r.Filter)
+	}
+	if r.PreviousFilter != "" {
+		s.Previous.Filter = json.RawMessage(r.PreviousFilter)
+	}
+	if r.EndedAt.Valid {
+		endedAt := r.EndedAt.Time
+		s.EndedAt = &endedAt
+	}
+	if r.ErrorMessage.Valid {
+		s.Error = r.ErrorMessage.String
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+/*
+AngelaMos | 2026
+cache.go
+*/
+
+package savedquery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const maxCacheEntries = 256
+
+type cacheEntry struct {
+	queryID   string
+	result    RunResult
+	cachedAt  time.Time
+	expiresAt time.Time
+}
+
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResultCache() *resultCache {
+	return &resultCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *resultCache) get(key string) (*RunResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	result := entry.result
+	result.Cached = true
+	result.CachedAt = &entry.cachedAt
+	result.ExpiresAt = &entry.expiresAt
+	return &result, true
+}
+
+func (c *resultCache) put(queryID, key string, result *RunResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= maxCacheEntries {
+		c.evict(now)
+	}
+
+	entry := cacheEntry{
+		queryID:   queryID,
+		result:    *result,
+		cachedAt:  now,
+		expiresAt: now.Add(ttl),
+	}
+	c.entries[key] = entry
+
+	result.CachedAt = &entry.cachedAt
+	result.ExpiresAt = &entry.expiresAt
+}
+
+func (c *resultCache) invalidate(queryID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.queryID == queryID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *resultCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.cachedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.cachedAt
+		}
+	}
+	if len(c.entries) >= maxCacheEntries {
+		delete(c.entries, oldestKey)
+	}
+}
+
+func cacheKey(ctx context.Context, q *SavedQuery, values map[string]json.RawMessage, input RunInput) string {
+	cluster := q.Cluster
+	if client, ok := mongodb.ClientFromContext(ctx); ok {
+		cluster = client.Name()
+	}
+
+	payload, _ := json.Marshal(map[string]any{
+		"id":          q.ID,
+		"updated_at":  q.UpdatedAt,
+		"cluster":     cluster,
+		"params":      values,
+		"limit":       input.Limit,
+		"after":       input.After,
+		"max_time_ms": input.MaxTimeMS,
+		"format":      input.Format,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,343 @@
+/*
+AngelaMos | 2026
+params.go
+*/
+
+package savedquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	ParamString   = "string"
+	ParamNumber   = "number"
+	ParamInt      = "int"
+	ParamBool     = "bool"
+	ParamDate     = "date"
+	ParamObjectID = "objectId"
+	ParamJSON     = "json"
+)
+
+var (
+	paramNamePattern   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	wholePattern       = regexp.MustCompile(`^\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}$`)
+)
+
+var paramSamples = map[string]json.RawMessage{
+	ParamString:   json.RawMessage(`"sample"`),
+	ParamNumber:   json.RawMessage(`0`),
+	ParamInt:      json.RawMessage(`0`),
+	ParamBool:     json.RawMessage(`false`),
+	ParamDate:     json.RawMessage(`{"$date":"1970-01-01T00:00:00Z"}`),
+	ParamObjectID: json.RawMessage(`{"$oid":"000000000000000000000000"}`),
+	ParamJSON:     json.RawMessage(`null`),
+}
+
+type Parameter struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Default     json.RawMessage `json:"default,omitempty"`
+}
+
+func validateParameters(params []Parameter) error {
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		if !paramNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("parameter name %q must start with a letter or '_' and contain only letters, digits or '_'", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("parameter %s is declared more than once", p.Name)
+		}
+		seen[p.Name] = true
+
+		if _, ok := paramSamples[p.Type]; !ok {
+			return fmt.Errorf("parameter %s has unsupported type %q", p.Name, p.Type)
+		}
+		if hasValue(p.Default) {
+			if _, err := coerce(p.Type, p.Default); err != nil {
+				return fmt.Errorf("default for parameter %s: %w", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func sampleValues(params []Parameter) map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage, len(params))
+	for _, p := range params {
+		values[p.Name] = paramSamples[p.Type]
+	}
+	return values
+}
+
+func resolve(params []Parameter, supplied map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	declared := make(map[string]bool, len(params))
+	values := make(map[string]json.RawMessage, len(params))
+
+	for _, p := range params {
+		declared[p.Name] = true
+
+		raw := supplied[p.Name]
+		if !hasValue(raw) {
+			raw = p.Default
+		}
+		if !hasValue(raw) {
+			if p.Required {
+				return nil, fmt.Errorf("parameter %s is required", p.Name)
+			}
+			values[p.Name] = json.RawMessage("null")
+			continue
+		}
+
+		value, err := coerce(p.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		values[p.Name] = value
+	}
+
+	for name := range supplied {
+		if !declared[name] {
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+
+	return values, nil
+}
+
+func coerce(kind string, raw json.RawMessage) (json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if !json.Valid(raw) {
+		return nil, errors.New("value is not valid JSON")
+	}
+
+	var s string
+	isString := json.Unmarshal(raw, &s) == nil
+
+	switch kind {
+	case ParamString:
+		if !isString {
+			return nil, errors.New("value must be a string")
+		}
+		return raw, nil
+
+	case ParamNumber:
+		text := string(raw)
+		if isString {
+			text = strings.TrimSpace(s)
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, errors.New("value must be a number")
+		}
+		return json.Marshal(f)
+
+	case ParamInt:
+		text := string(raw)
+		if isString {
+			text = strings.TrimSpace(s)
+		}
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, errors.New("value must be an integer")
+		}
+		return json.Marshal(n)
+
+	case ParamBool:
+		text := string(raw)
+		if isString {
+			text = s
+		}
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, errors.New("value must be true or false")
+		}
+		return json.Marshal(b)
+
+	case ParamDate:
+		if isString {
+			t, err := parseDate(s)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(map[string]string{"$date": t.UTC().Format(time.RFC3339Nano)})
+		}
+		if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return json.Marshal(map[string]any{"$date": map[string]string{"$numberLong": strconv.FormatInt(n, 10)}})
+		}
+		if v, err := mongodb.ParseExtJSONValue(raw); err == nil && v.Type == bson.TypeDateTime {
+			return raw, nil
+		}
+		return nil, errors.New("value must be an RFC 3339 date, a YYYY-MM-DD date or epoch milliseconds")
+
+	case ParamObjectID:
+		if isString {
+			if _, err := bson.ObjectIDFromHex(s); err != nil {
+				return nil, errors.New("value must be a 24 character hex ObjectId")
+			}
+			return json.Marshal(map[string]string{"$oid": s})
+		}
+		if v, err := mongodb.ParseExtJSONValue(raw); err == nil && v.Type == bson.TypeObjectID {
+			return raw, nil
+		}
+		return nil, errors.New("value must be a 24 character hex ObjectId")
+
+	case ParamJSON:
+		if _, err := mongodb.ParseExtJSONValue(raw); err != nil {
+			return nil, errors.New("value must be extended JSON")
+		}
+		return raw, nil
+	}
+
+	return nil, fmt.Errorf("unsupported parameter type %q", kind)
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("value must be an RFC 3339 date, a YYYY-MM-DD date or epoch milliseconds")
+}
+
+func placeholders(template json.RawMessage) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllSubmatch(template, -1) {
+		name := string(m[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func render(template json.RawMessage, values map[string]json.RawMessage) (json.RawMessage, error) {
+	template = bytes.TrimSpace(template)
+	if len(template) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(template) {
+		return nil, errors.New("template is not valid JSON")
+	}
+	if !bytes.Contains(template, []byte("{{")) {
+		return template, nil
+	}
+
+	var out bytes.Buffer
+	for i := 0; i < len(template); {
+		if template[i] != '"' {
+			out.WriteByte(template[i])
+			i++
+			continue
+		}
+
+		end := stringEnd(template, i)
+		literal := template[i:end]
+		i = end
+
+		if !bytes.Contains(literal, []byte("{{")) {
+			out.Write(literal)
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(literal, &s); err != nil {
+			return nil, err
+		}
+
+		if placeholderPattern.MatchString(s) && isObjectKey(template, i) {
+			return nil, fmt.Errorf("placeholders are not allowed in object keys: %q", s)
+		}
+
+		if m := wholePattern.FindStringSubmatch(s); m != nil {
+			value, ok := values[m[1]]
+			if !ok {
+				return nil, fmt.Errorf("unknown parameter %s", m[1])
+			}
+			out.Write(value)
+			continue
+		}
+
+		var missing string
+		replaced := placeholderPattern.ReplaceAllStringFunc(s, func(p string) string {
+			name := placeholderPattern.FindStringSubmatch(p)[1]
+			value, ok := values[name]
+			if !ok {
+				missing = name
+				return p
+			}
+			return inlineValue(value)
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("unknown parameter %s", missing)
+		}
+
+		encoded, err := json.Marshal(replaced)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(encoded)
+	}
+
+	return out.Bytes(), nil
+}
+
+func stringEnd(data []byte, start int) int {
+	for j := start + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(data)
+}
+
+func isObjectKey(data []byte, pos int) bool {
+	for ; pos < len(data); pos++ {
+		switch data[pos] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func inlineValue(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	if string(value) == "null" {
+		return ""
+	}
+	return string(value)
+}
+
+func hasValue(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && string(trimmed) != "null"
+}
@@ -0,0 +1,75 @@
+/*
+AngelaMos | 2026
+params_test.go
+*/
+
+package savedquery
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	values := map[string]json.RawMessage{
+		"status": json.RawMessage(`"active"`),
+		"age":    json.RawMessage(`21`),
+		"field":  json.RawMessage(`"$where"`),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "whole string value is replaced with typed json",
+			template: `{"age": {"$gte": "{{age}}"}}`,
+			want:     `{"age": {"$gte": 21}}`,
+		},
+		{
+			name:     "placeholder inside a string is interpolated",
+			template: `{"label": "status is {{ status }}"}`,
+			want:     `{"label": "status is active"}`,
+		},
+		{
+			name:     "placeholder as an object key is rejected",
+			template: `{"{{field}}": "sleep(1000)"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "placeholder inside an object key is rejected",
+			template: `{"meta.{{field}}": 1}`,
+			wantErr:  true,
+		},
+		{
+			name:     "nested object key placeholder is rejected",
+			template: `{"$and": [{"{{field}}" : {"$exists": true}}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown parameter is rejected",
+			template: `{"x": "{{missing}}"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(json.RawMessage(tt.template), values)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("render(%s) = %s, want error", tt.template, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("render(%s) returned error: %v", tt.template, err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("render(%s) = %s, want %s", tt.template, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,565 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package savedquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/documents"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	KindFind      = "find"
+	KindAggregate = "aggregate"
+
+	MaxNameLength = 100
+	MaxTags       = 20
+	MaxTagLength  = 50
+	MaxCacheTTL   = 24 * time.Hour
+)
+
+type savedQueryRepository interface {
+	Create(ctx context.Context, q *sqlite.SavedQuery) error
+	Update(ctx context.Context, q *sqlite.SavedQuery) error
+	GetByID(ctx context.Context, id string) (*sqlite.SavedQuery, error)
+	GetByName(ctx context.Context, clusterName, dbName, collName, name string) (*sqlite.SavedQuery, error)
+	List(ctx context.Context, f sqlite.SavedQueryFilter) ([]*sqlite.SavedQuery, error)
+	ListTags(ctx context.Context) ([]sqlite.SavedQueryTag, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type documentBrowser interface {
+	Browse(ctx context.Context, dbName, collName string, input documents.BrowseInput) (*documents.Page, error)
+}
+
+type pipelineRunner interface {
+	Run(ctx context.Context, dbName, collName string, input documents.AggregateInput, actor documents.Actor) (*documents.AggregateResult, error)
+}
+
+type clusterResolver interface {
+	Get(name string) (*mongodb.Client, error)
+}
+
+type Service struct {
+	repo       savedQueryRepository
+	browser    documentBrowser
+	aggregator pipelineRunner
+	clusters   clusterResolver
+	cache      *resultCache
+	logger     *slog.Logger
+}
+
+func NewService(repo savedQueryRepository, browser documentBrowser, aggregator pipelineRunner, clusters clusterResolver, logger *slog.Logger) *Service {
+	return &Service{
+		repo:       repo,
+		browser:    browser,
+		aggregator: aggregator,
+		clusters:   clusters,
+		cache:      newResultCache(),
+		logger:     logger,
+	}
+}
+
+type SavedQuery struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description,omitempty"`
+	Cluster         string          `json:"cluster,omitempty"`
+	Database        string          `json:"database"`
+	Collection      string          `json:"collection"`
+	Kind            string          `json:"kind"`
+	Filter          json.RawMessage `json:"filter,omitempty"`
+	Sort            json.RawMessage `json:"sort,omitempty"`
+	Projection      json.RawMessage `json:"projection,omitempty"`
+	Pipeline        json.RawMessage `json:"pipeline,omitempty"`
+	Parameters      []Parameter     `json:"parameters"`
+	Tags            []string        `json:"tags"`
+	CacheTTLSeconds int64           `json:"cache_ttl_seconds"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type Input struct {
+	Name            string
+	Description     string
+	Cluster         string
+	Database        string
+	Collection      string
+	Kind            string
+	Filter          json.RawMessage
+	Sort            json.RawMessage
+	Projection      json.RawMessage
+	Pipeline        json.RawMessage
+	Parameters      []Parameter
+	Tags            []string
+	CacheTTLSeconds int64
+}
+
+type ListFilter struct {
+	Cluster    string
+	Database   string
+	Collection string
+	Tag        string
+}
+
+type Tag struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type RunInput struct {
+	Params    map[string]json.RawMessage
+	Limit     int
+	After     string
+	MaxTimeMS int64
+	Format    string
+	Refresh   bool
+}
+
+type RunResult struct {
+	QueryID    string            `json:"query_id"`
+	Kind       string            `json:"kind"`
+	Database   string            `json:"database"`
+	Collection string            `json:"collection"`
+	Documents  []json.RawMessage `json:"documents"`
+	Count      int               `json:"count"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	DurationMs int64             `json:"duration_ms"`
+	Cached     bool              `json:"cached"`
+	CachedAt   *time.Time        `json:"cached_at,omitempty"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"`
+}
+
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]SavedQuery, error) {
+	rows, err := s.repo.List(ctx, sqlite.SavedQueryFilter{
+		ClusterName:    filter.Cluster,
+		DatabaseName:   filter.Database,
+		CollectionName: filter.Collection,
+		Tag:            filter.Tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]SavedQuery, 0, len(rows))
+	for _, row := range rows {
+		q, err := fromRow(row)
+		if err != nil {
+			s.logger.Warn("skipping unreadable saved query", "id", row.ID, "error", err)
+			continue
+		}
+		queries = append(queries, *q)
+	}
+	return queries, nil
+}
+
+func (s *Service) Tags(ctx context.Context) ([]Tag, error) {
+	rows, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]Tag, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, Tag{Tag: row.Tag, Count: row.Count})
+	}
+	return tags, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*SavedQuery, error) {
+	row, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, core.NotFoundError("saved query")
+	}
+	return fromRow(row)
+}
+
+func (s *Service) Create(ctx context.Context, input Input) (*SavedQuery, error) {
+	q, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, q, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	q.ID = uuid.New().String()
+	q.CreatedAt = now
+	q.UpdatedAt = now
+
+	row, err := toRow(q)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, row); err != nil {
+		return nil, fmt.Errorf("persist saved query: %w", err)
+	}
+
+	return q, nil
+}
+
+func (s *Service) Update(ctx context.Context, id string, input Input) (*SavedQuery, error) {
+	existing, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, q, id); err != nil {
+		return nil, err
+	}
+
+	q.ID = existing.ID
+	q.CreatedAt = existing.CreatedAt
+	q.UpdatedAt = time.Now()
+
+	row, err := toRow(q)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, row); err != nil {
+		return nil, fmt.Errorf("persist saved query: %w", err)
+	}
+	s.cache.invalidate(id)
+
+	return q, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.cache.invalidate(id)
+	return nil
+}
+
+func (s *Service) Run(ctx context.Context, id string, input RunInput, actor documents.Actor) (*RunResult, error) {
+	q, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resolve(q.Parameters, input.Params)
+	if err != nil {
+		return nil, core.ValidationError(err.Error())
+	}
+
+	if q.Cluster != "" {
+		client, err := s.clusters.Get(q.Cluster)
+		if err != nil {
+			return nil, core.ValidationError("unknown cluster " + q.Cluster)
+		}
+		ctx = mongodb.WithClient(ctx, client)
+	}
+
+	ttl := time.Duration(q.CacheTTLSeconds) * time.Second
+	key := cacheKey(ctx, q, values, input)
+	if ttl > 0 && !input.Refresh {
+		if result, ok := s.cache.get(key); ok {
+			return result, nil
+		}
+	}
+
+	result, err := s.execute(ctx, q, values, input, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		s.cache.put(q.ID, key, result, ttl)
+	}
+	return result, nil
+}
+
+func (s *Service) execute(ctx context.Context, q *SavedQuery, values map[string]json.RawMessage, input RunInput, actor documents.Actor) (*RunResult, error) {
+	result := &RunResult{
+		QueryID:    q.ID,
+		Kind:       q.Kind,
+		Database:   q.Database,
+		Collection: q.Collection,
+	}
+	start := time.Now()
+
+	switch q.Kind {
+	case KindFind:
+		filter, err := render(q.Filter, values)
+		if err != nil {
+			return nil, core.ValidationError("filter: " + err.Error())
+		}
+		sort, err := render(q.Sort, values)
+		if err != nil {
+			return nil, core.ValidationError("sort: " + err.Error())
+		}
+		projection, err := render(q.Projection, values)
+		if err != nil {
+			return nil, core.ValidationError("projection: " + err.Error())
+		}
+
+		page, err := s.browser.Browse(ctx, q.Database, q.Collection, documents.BrowseInput{
+			Filter:     filter,
+			Sort:       sort,
+			Projection: projection,
+			Limit:      input.Limit,
+			After:      input.After,
+			Format:     input.Format,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Documents = page.Documents
+		result.HasMore = page.HasMore
+		result.NextCursor = page.NextCursor
+
+	case KindAggregate:
+		if input.After != "" {
+			return nil, core.ValidationError("after cursors are only supported for find queries")
+		}
+		pipeline, err := render(q.Pipeline, values)
+		if err != nil {
+			return nil, core.ValidationError("pipeline: " + err.Error())
+		}
+
+		agg, err := s.aggregator.Run(ctx, q.Database, q.Collection, documents.AggregateInput{
+			Pipeline:  pipeline,
+			MaxTimeMS: input.MaxTimeMS,
+			Limit:     input.Limit,
+			Format:    input.Format,
+		}, actor)
+		if err != nil {
+			return nil, err
+		}
+		result.Documents = agg.Documents
+		result.HasMore = agg.Truncated
+	}
+
+	result.Count = len(result.Documents)
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+func (s *Service) validate(input Input) (*SavedQuery, error) {
+	q := &SavedQuery{
+		Name:            strings.TrimSpace(input.Name),
+		Description:     strings.TrimSpace(input.Description),
+		Cluster:         input.Cluster,
+		Database:        input.Database,
+		Collection:      input.Collection,
+		Kind:            input.Kind,
+		Filter:          input.Filter,
+		Sort:            input.Sort,
+		Projection:      input.Projection,
+		Pipeline:        input.Pipeline,
+		Parameters:      input.Parameters,
+		CacheTTLSeconds: input.CacheTTLSeconds,
+	}
+	if q.Kind == "" {
+		q.Kind = KindFind
+	}
+	if q.Parameters == nil {
+		q.Parameters = []Parameter{}
+	}
+
+	if q.Name == "" || len(q.Name) > MaxNameLength {
+		return nil, core.ValidationError(fmt.Sprintf("name must be 1-%d characters", MaxNameLength))
+	}
+	if q.Database == "" {
+		return nil, core.ValidationError("database is required")
+	}
+	if q.Collection == "" {
+		return nil, core.ValidationError("collection is required")
+	}
+	if q.Cluster != "" {
+		if _, err := s.clusters.Get(q.Cluster); err != nil {
+			return nil, core.ValidationError("unknown cluster " + q.Cluster)
+		}
+	}
+	if q.CacheTTLSeconds < 0 || time.Duration(q.CacheTTLSeconds)*time.Second > MaxCacheTTL {
+		return nil, core.ValidationError(fmt.Sprintf("cache_ttl_seconds must be between 0 and %d", int64(MaxCacheTTL.Seconds())))
+	}
+
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, core.ValidationError(err.Error())
+	}
+	q.Tags = tags
+
+	if err := validateParameters(q.Parameters); err != nil {
+		return nil, core.ValidationError(err.Error())
+	}
+
+	templates := map[string]json.RawMessage{
+		"filter":     q.Filter,
+		"sort":       q.Sort,
+		"projection": q.Projection,
+		"pipeline":   q.Pipeline,
+	}
+
+	switch q.Kind {
+	case KindFind:
+		if hasValue(q.Pipeline) {
+			return nil, core.ValidationError("pipeline is only allowed for aggregate queries")
+		}
+		q.Pipeline = nil
+	case KindAggregate:
+		if !hasValue(q.Pipeline) {
+			return nil, core.ValidationError("pipeline is required for aggregate queries")
+		}
+		if hasValue(q.Filter) || hasValue(q.Sort) || hasValue(q.Projection) {
+			return nil, core.ValidationError("filter, sort and projection are only allowed for find queries; use pipeline stages instead")
+		}
+		q.Filter, q.Sort, q.Projection = nil, nil, nil
+	default:
+		return nil, core.ValidationError("kind must be find or aggregate")
+	}
+
+	declared := make(map[string]bool, len(q.Parameters))
+	for _, p := range q.Parameters {
+		declared[p.Name] = true
+	}
+
+	samples := sampleValues(q.Parameters)
+	for field, template := range templates {
+		if !hasValue(template) {
+			continue
+		}
+		for _, name := range placeholders(template) {
+			if !declared[name] {
+				return nil, core.ValidationError(fmt.Sprintf("%s references undeclared parameter %s", field, name))
+			}
+		}
+
+		rendered, err := render(template, samples)
+		if err != nil {
+			return nil, core.ValidationError(fmt.Sprintf("%s: %s", field, err.Error()))
+		}
+		if field == "pipeline" {
+			_, err = mongodb.ParseExtJSONPipeline(rendered)
+		} else {
+			_, err = mongodb.ParseExtJSONDocument(rendered)
+		}
+		if err != nil {
+			return nil, core.ValidationError(fmt.Sprintf("%s must be valid extended JSON", field))
+		}
+	}
+
+	return q, nil
+}
+
+func (s *Service) checkUnique(ctx context.Context, q *SavedQuery, id string) error {
+	existing, err := s.repo.GetByName(ctx, q.Cluster, q.Database, q.Collection, q.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return core.DuplicateError("saved query " + q.Name)
+	}
+	return nil
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.Contains(out, tag) {
+			continue
+		}
+		if len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("tags cannot be longer than %d characters", MaxTagLength)
+		}
+		out = append(out, tag)
+	}
+	if len(out) > MaxTags {
+		return nil, fmt.Errorf("a saved query can have at most %d tags", MaxTags)
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func fromRow(row *sqlite.SavedQuery) (*SavedQuery, error) {
+	q := &SavedQuery{
+		ID:              row.ID,
+		Name:            row.Name,
+		Description:     row.Description,
+		Cluster:         row.ClusterName,
+		Database:        row.DatabaseName,
+		Collection:      row.CollectionName,
+		Kind:            row.Kind,
+		CacheTTLSeconds: row.CacheTTLSeconds,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+	if row.Filter != "" {
+		q.Filter = json.RawMessage(row.Filter)
+	}
+	if row.Sort != "" {
+		q.Sort = json.RawMessage(row.Sort)
+	}
+	if row.Projection != "" {
+		q.Projection = json.RawMessage(row.Projection)
+	}
+	if row.Pipeline != "" {
+		q.Pipeline = json.RawMessage(row.Pipeline)
+	}
+	if err := json.Unmarshal([]byte(row.Parameters), &q.Parameters); err != nil {
+		return nil, fmt.Errorf("decode parameters: %w", err)
+	}
+	if err := json.Unmarshal([]byte(row.Tags), &q.Tags); err != nil {
+		return nil, fmt.Errorf("decode tags: %w", err)
+	}
+	return q, nil
+}
+
+func toRow(q *SavedQuery) (*sqlite.SavedQuery, error) {
+	params, err := json.Marshal(q.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("encode parameters: %w", err)
+	}
+	tags, err := json.Marshal(q.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("encode tags: %w", err)
+	}
+
+	return &sqlite.SavedQuery{
+		ID:              q.ID,
+		Name:            q.Name,
+		Description:     q.Description,
+		ClusterName:     q.Cluster,
+		DatabaseName:    q.Database,
+		CollectionName:  q.Collection,
+		Kind:            q.Kind,
+		Filter:          string(q.Filter),
+		Sort:            string(q.Sort),
+		Projection:      string(q.Projection),
+		Pipeline:        string(q.Pipeline),
+		Parameters:      string(params),
+		Tags:            string(tags),
+		CacheTTLSeconds: q.CacheTTLSeconds,
+		CreatedAt:       q.CreatedAt,
+		UpdatedAt:       q.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,100 @@
+/*
+AngelaMos | 2026
+audit_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(client *Client) *AuditRepository {
+	return &AuditRepository{db: client.DB()}
+}
+
+type AuditEntry struct {
+	ID           string
+	Action       string
+	Resource     string
+	ResourceID   string
+	ClusterName  string
+	DatabaseName string
+	Details      sql.NullString
+	RequestID    string
+	RemoteAddr   string
+	Status       string
+	ErrorMessage sql.NullString
+	CreatedAt    time.Time
+}
+
+func (r *AuditRepository) Create(ctx context.Context, e *AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (id, action, resource, resource_id, cluster_name, database_name, details, request_id, remote_addr, status, error_message, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		e.ID,
+		e.Action,
+		e.Resource,
+		e.ResourceID,
+		e.ClusterName,
+		e.DatabaseName,
+		e.Details,
+		e.RequestID,
+		e.RemoteAddr,
+		e.Status,
+		e.ErrorMessage,
+		e.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) ListRecent(ctx context.Context, action string, limit int) ([]*AuditEntry, error) {
+	query := `
+		SELECT id, action, resource, resource_id, cluster_name, database_name, details, request_id, remote_addr, status, error_message, created_at
+		FROM audit_log
+		WHERE (? = '' OR action = ?)
+		ORDER BY created_at DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, action, action, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.Action,
+			&e.Resource,
+			&e.ResourceID,
+			&e.ClusterName,
+			&e.DatabaseName,
+			&e.Details,
+			&e.RequestID,
+			&e.RemoteAddr,
+			&e.Status,
+			&e.ErrorMessage,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
@@ -22,6 +22,7 @@ func NewBackupRepository(client *Client) *BackupRepository {
 
 type Backup struct {
 	ID           string
+	ClusterName  string
 	DatabaseName string
 	FilePath     string
 	SizeBytes    int64
@@ -34,11 +35,12 @@ type Backup struct {
 
 func (r *BackupRepository) Create(ctx context.Context, b *Backup) error {
 	query := `
-		INSERT INTO backups (id, database_name, file_path, size_bytes, started_at, status, triggered_by)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`
+		INSERT INTO backups (id, cluster_name, database_name, file_path, size_bytes, started_at, status, triggered_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		b.ID,
+		b.ClusterName,
 		b.DatabaseName,
 		b.FilePath,
 		b.SizeBytes,
@@ -70,13 +72,14 @@ func (r *BackupRepository) UpdateStatus(ctx context.Context, id, status, filePat
 
 func (r *BackupRepository) GetByID(ctx context.Context, id string) (*Backup, error) {
 	query := `
-		SELECT id, database_name, file_path, size_bytes, started_at, completed_at, status, error_message, triggered_by
+		SELECT id, cluster_name, database_name, file_path, size_bytes, started_at, completed_at, status, error_message, triggered_by
 		FROM backups
 		WHERE id = ?`
 
 	var b Backup
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&b.ID,
+		&b.ClusterName,
 		&b.DatabaseName,
 		&b.FilePath,
 		&b.SizeBytes,
@@ -97,7 +100,7 @@ func (r *BackupRepository) GetByID(ctx context.Context, id string) (*Backup, err
 
 func (r *BackupRepository) ListRecent(ctx context.Context, limit int) ([]*Backup, error) {
 	query := `
-		SELECT id, database_name, file_path, size_bytes, started_at, completed_at, status, error_message, triggered_by
+		SELECT id, cluster_name, database_name, file_path, size_bytes, started_at, completed_at, status, error_message, triggered_by
 		FROM backups
 		ORDER BY started_at DESC
 		LIMIT ?`
@@ -113,6 +116,7 @@ func (r *BackupRepository) ListRecent(ctx context.Context, limit int) ([]*Backup
 		var b Backup
 		err := rows.Scan(
 			&b.ID,
+			&b.ClusterName,
 			&b.DatabaseName,
 			&b.FilePath,
 			&b.SizeBytes,
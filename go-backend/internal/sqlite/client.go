@@ -86,6 +86,153 @@ func (c *Client) migrate() error {
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_backups_started_at ON backups(started_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_backups_status ON backups(status)`,
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id TEXT PRIMARY KEY,
+			action TEXT NOT NULL,
+			resource TEXT NOT NULL,
+			resource_id TEXT NOT NULL,
+			database_name TEXT NOT NULL DEFAULT '',
+			details TEXT,
+			request_id TEXT NOT NULL DEFAULT '',
+			remote_addr TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			error_message TEXT,
+			created_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action)`,
+		`CREATE TABLE IF NOT EXISTS clusters (
+			name TEXT PRIMARY KEY,
+			uri TEXT NOT NULL,
+			database_name TEXT NOT NULL,
+			max_pool_size INTEGER NOT NULL DEFAULT 0,
+			min_pool_size INTEGER NOT NULL DEFAULT 0,
+			connect_timeout_ms INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS kpi_definitions (
+			name TEXT PRIMARY KEY,
+			label TEXT NOT NULL DEFAULT '',
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL DEFAULT '',
+			collection_name TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			filter TEXT NOT NULL DEFAULT '',
+			pipeline TEXT NOT NULL DEFAULT '',
+			exclusions TEXT NOT NULL DEFAULT '[]',
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS kpi_samples (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kpi_name TEXT NOT NULL,
+			cluster_name TEXT NOT NULL DEFAULT '',
+			value REAL NOT NULL,
+			error_message TEXT,
+			sampled_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_kpi_samples_name_sampled_at ON kpi_samples(kpi_name, sampled_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS slow_queries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entry_hash TEXT NOT NULL UNIQUE,
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL,
+			namespace TEXT NOT NULL,
+			op TEXT NOT NULL,
+			millis INTEGER NOT NULL,
+			plan_summary TEXT NOT NULL DEFAULT '',
+			keys_examined INTEGER NOT NULL DEFAULT 0,
+			docs_examined INTEGER NOT NULL DEFAULT 0,
+			nreturned INTEGER NOT NULL DEFAULT 0,
+			num_yields INTEGER NOT NULL DEFAULT 0,
+			response_length INTEGER NOT NULL DEFAULT 0,
+			has_sort_stage INTEGER NOT NULL DEFAULT 0,
+			query_hash TEXT NOT NULL DEFAULT '',
+			client TEXT NOT NULL DEFAULT '',
+			user_name TEXT NOT NULL DEFAULT '',
+			app_name TEXT NOT NULL DEFAULT '',
+			command TEXT NOT NULL DEFAULT '',
+			ts TIMESTAMP NOT NULL,
+			collected_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_slow_queries_ts ON slow_queries(ts DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_slow_queries_cluster_db_ts ON slow_queries(cluster_name, database_name, ts DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_slow_queries_namespace_ts ON slow_queries(namespace, ts DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_slow_queries_millis ON slow_queries(millis DESC)`,
+		`CREATE TABLE IF NOT EXISTS profiler_sessions (
+			id TEXT PRIMARY KEY,
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL,
+			level INTEGER NOT NULL,
+			slow_ms INTEGER NOT NULL DEFAULT 0,
+			sample_rate REAL NOT NULL DEFAULT 1,
+			filter TEXT NOT NULL DEFAULT '',
+			previous_level INTEGER NOT NULL,
+			previous_slow_ms INTEGER NOT NULL DEFAULT 0,
+			previous_sample_rate REAL NOT NULL DEFAULT 1,
+			previous_filter TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			started_at TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			ended_at TIMESTAMP,
+			error_message TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_profiler_sessions_status ON profiler_sessions(status)`,
+		`CREATE INDEX IF NOT EXISTS idx_profiler_sessions_started_at ON profiler_sessions(started_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS document_jobs (
+			id TEXT PRIMARY KEY,
+			kind TEXT NOT NULL,
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL,
+			collection_name TEXT NOT NULL,
+			format TEXT NOT NULL,
+			options TEXT NOT NULL DEFAULT '{}',
+			file_path TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL,
+			document_count INTEGER NOT NULL DEFAULT 0,
+			size_bytes INTEGER NOT NULL DEFAULT 0,
+			started_at TIMESTAMP NOT NULL,
+			completed_at TIMESTAMP,
+			error_message TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_document_jobs_started_at ON document_jobs(started_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_document_jobs_status ON document_jobs(status)`,
+		`CREATE TABLE IF NOT EXISTS saved_queries (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL,
+			collection_name TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			filter TEXT NOT NULL DEFAULT '',
+			sort TEXT NOT NULL DEFAULT '',
+			projection TEXT NOT NULL DEFAULT '',
+			pipeline TEXT NOT NULL DEFAULT '',
+			parameters TEXT NOT NULL DEFAULT '[]',
+			tags TEXT NOT NULL DEFAULT '[]',
+			cache_ttl_seconds INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_queries_scope_name ON saved_queries(cluster_name, database_name, collection_name, name)`,
+		`CREATE TABLE IF NOT EXISTS schema_snapshots (
+			id TEXT PRIMARY KEY,
+			cluster_name TEXT NOT NULL DEFAULT '',
+			database_name TEXT NOT NULL,
+			collection_name TEXT NOT NULL,
+			sample_size INTEGER NOT NULL DEFAULT 0,
+			total_documents INTEGER NOT NULL DEFAULT 0,
+			field_count INTEGER NOT NULL DEFAULT 0,
+			fields TEXT NOT NULL DEFAULT '[]',
+			previous_id TEXT,
+			drift TEXT,
+			change_count INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_schema_snapshots_scope_created_at ON schema_snapshots(cluster_name, database_name, collection_name, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_schema_snapshots_created_at ON schema_snapshots(created_at DESC)`,
 	}
 
 	for _, migration := range migrations {
@@ -94,5 +241,53 @@ func (c *Client) migrate() error {
 		}
 	}
 
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"backups", "cluster_name", "TEXT NOT NULL DEFAULT ''"},
+		{"audit_log", "cluster_name", "TEXT NOT NULL DEFAULT ''"},
+	}
+
+	for _, col := range columns {
+		if err := c.addColumnIfMissing(col.table, col.column, col.definition); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) addColumnIfMissing(table, column, definition string) error {
+	rows, err := c.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("table info %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &pk); err != nil {
+			return fmt.Errorf("scan table info %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("table info %s: %w", table, err)
+	}
+
+	if _, err := c.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
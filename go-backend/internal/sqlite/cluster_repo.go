@@ -0,0 +1,95 @@
+/*
+AngelaMos | 2026
+cluster_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ClusterRepository struct {
+	db *sql.DB
+}
+
+func NewClusterRepository(client *Client) *ClusterRepository {
+	return &ClusterRepository{db: client.DB()}
+}
+
+type Cluster struct {
+	Name             string
+	URI              string
+	DatabaseName     string
+	MaxPoolSize      int64
+	MinPoolSize      int64
+	ConnectTimeoutMs int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (r *ClusterRepository) Create(ctx context.Context, c *Cluster) error {
+	query := `
+		INSERT INTO clusters (name, uri, database_name, max_pool_size, min_pool_size, connect_timeout_ms, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		c.Name,
+		c.URI,
+		c.DatabaseName,
+		c.MaxPoolSize,
+		c.MinPoolSize,
+		c.ConnectTimeoutMs,
+		c.CreatedAt,
+		c.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert cluster: %w", err)
+	}
+	return nil
+}
+
+func (r *ClusterRepository) List(ctx context.Context) ([]*Cluster, error) {
+	query := `
+		SELECT name, uri, database_name, max_pool_size, min_pool_size, connect_timeout_ms, created_at, updated_at
+		FROM clusters
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list clusters: %w", err)
+	}
+	defer rows.Close()
+
+	var clusters []*Cluster
+	for rows.Next() {
+		var c Cluster
+		err := rows.Scan(
+			&c.Name,
+			&c.URI,
+			&c.DatabaseName,
+			&c.MaxPoolSize,
+			&c.MinPoolSize,
+			&c.ConnectTimeoutMs,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan cluster: %w", err)
+		}
+		clusters = append(clusters, &c)
+	}
+	return clusters, nil
+}
+
+func (r *ClusterRepository) Delete(ctx context.Context, name string) error {
+	query := `DELETE FROM clusters WHERE name = ?`
+	_, err := r.db.ExecContext(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("delete cluster: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+AngelaMos | 2026
+document_job_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DocumentJobRepository struct {
+	db *sql.DB
+}
+
+func NewDocumentJobRepository(client *Client) *DocumentJobRepository {
+	return &DocumentJobRepository{db: client.DB()}
+}
+
+type DocumentJob struct {
+	ID             string
+	Kind           string
+	ClusterName    string
+	DatabaseName   string
+	CollectionName string
+	Format         string
+	Options        string
+	FilePath       string
+	Status         string
+	DocumentCount  int64
+	SizeBytes      int64
+	StartedAt      time.Time
+	CompletedAt    sql.NullTime
+	ErrorMessage   sql.NullString
+}
+
+const documentJobColumns = `id, kind, cluster_name, database_name, collection_name, format, options, file_path, status, document_count, size_bytes, started_at, completed_at, error_message`
+
+func (r *DocumentJobRepository) Create(ctx context.Context, j *DocumentJob) error {
+	query := `
+		INSERT INTO document_jobs (` + documentJobColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		j.ID,
+		j.Kind,
+		j.ClusterName,
+		j.DatabaseName,
+		j.CollectionName,
+		j.Format,
+		j.Options,
+		j.FilePath,
+		j.Status,
+		j.DocumentCount,
+		j.SizeBytes,
+		j.StartedAt,
+		j.CompletedAt,
+		j.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("insert document job: %w", err)
+	}
+	return nil
+}
+
+func (r *DocumentJobRepository) Finish(ctx context.Context, id, status string, documentCount, sizeBytes int64, completedAt time.Time, errorMsg string) error {
+	query := `
+		UPDATE document_jobs
+		SET status = ?, document_count = ?, size_bytes = ?, completed_at = ?, error_message = ?
+		WHERE id = ?`
+
+	errMsgNull := sql.NullString{String: errorMsg, Valid: errorMsg != ""}
+
+	_, err := r.db.ExecContext(ctx, query, status, documentCount, sizeBytes, completedAt, errMsgNull, id)
+	if err != nil {
+		return fmt.Errorf("finish document job: %w", err)
+	}
+	return nil
+}
+
+func (r *DocumentJobRepository) GetByID(ctx context.Context, id string) (*DocumentJob, error) {
+	query := `SELECT ` + documentJobColumns + ` FROM document_jobs WHERE id = ?`
+
+	j, err := scanDocumentJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get document job: %w", err)
+	}
+	return j, nil
+}
+
+func (r *DocumentJobRepository) ListRecent(ctx context.Context, kind string, limit int) ([]*DocumentJob, error) {
+	query := `
+		SELECT ` + documentJobColumns + ` FROM document_jobs
+		WHERE (? = '' OR kind = ?)
+		ORDER BY started_at DESC
+		LIMIT ?`
+	return r.list(ctx, query, kind, kind, limit)
+}
+
+func (r *DocumentJobRepository) ListByStatus(ctx context.Context, status string) ([]*DocumentJob, error) {
+	query := `SELECT ` + documentJobColumns + ` FROM document_jobs WHERE status = ? ORDER BY started_at`
+	return r.list(ctx, query, status)
+}
+
+func (r *DocumentJobRepository) ListCompletedBefore(ctx context.Context, before time.Time) ([]*DocumentJob, error) {
+	query := `SELECT ` + documentJobColumns + ` FROM document_jobs WHERE completed_at IS NOT NULL AND completed_at < ? ORDER BY started_at`
+	return r.list(ctx, query, before)
+}
+
+func (r *DocumentJobRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM document_jobs WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete document job: %w", err)
+	}
+	return nil
+}
+
+func (r *DocumentJobRepository) list(ctx context.Context, query string, args ...any) ([]*DocumentJob, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list document jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*DocumentJob
+	for rows.Next() {
+		j, err := scanDocumentJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan document job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+func scanDocumentJob(row rowScanner) (*DocumentJob, error) {
+	var j DocumentJob
+	err := row.Scan(
+		&j.ID,
+		&j.Kind,
+		&j.ClusterName,
+		&j.DatabaseName,
+		&j.CollectionName,
+		&j.Format,
+		&j.Options,
+		&j.FilePath,
+		&j.Status,
+		&j.DocumentCount,
+		&j.SizeBytes,
+		&j.StartedAt,
+		&j.CompletedAt,
+		&j.ErrorMessage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
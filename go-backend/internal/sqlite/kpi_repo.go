@@ -0,0 +1,171 @@
+/*
+AngelaMos | 2026
+kpi_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type KPIRepository struct {
+	db *sql.DB
+}
+
+func NewKPIRepository(client *Client) *KPIRepository {
+	return &KPIRepository{db: client.DB()}
+}
+
+type KPIDefinition struct {
+	Name           string
+	Label          string
+	ClusterName    string
+	DatabaseName   string
+	CollectionName string
+	Kind           string
+	Filter         string
+	Pipeline       string
+	Exclusions     string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type KPISample struct {
+	KPIName      string
+	ClusterName  string
+	Value        float64
+	ErrorMessage sql.NullString
+	SampledAt    time.Time
+}
+
+func (r *KPIRepository) CreateDefinition(ctx context.Context, d *KPIDefinition) error {
+	query := `
+		INSERT INTO kpi_definitions (name, label, cluster_name, database_name, collection_name, kind, filter, pipeline, exclusions, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		d.Name,
+		d.Label,
+		d.ClusterName,
+		d.DatabaseName,
+		d.CollectionName,
+		d.Kind,
+		d.Filter,
+		d.Pipeline,
+		d.Exclusions,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert kpi definition: %w", err)
+	}
+	return nil
+}
+
+func (r *KPIRepository) ListDefinitions(ctx context.Context) ([]*KPIDefinition, error) {
+	query := `
+		SELECT name, label, cluster_name, database_name, collection_name, kind, filter, pipeline, exclusions, created_at, updated_at
+		FROM kpi_definitions
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list kpi definitions: %w", err)
+	}
+	defer rows.Close()
+
+	var definitions []*KPIDefinition
+	for rows.Next() {
+		var d KPIDefinition
+		err := rows.Scan(
+			&d.Name,
+			&d.Label,
+			&d.ClusterName,
+			&d.DatabaseName,
+			&d.CollectionName,
+			&d.Kind,
+			&d.Filter,
+			&d.Pipeline,
+			&d.Exclusions,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan kpi definition: %w", err)
+		}
+		definitions = append(definitions, &d)
+	}
+	return definitions, nil
+}
+
+func (r *KPIRepository) DeleteDefinition(ctx context.Context, name string) error {
+	query := `DELETE FROM kpi_definitions WHERE name = ?`
+	_, err := r.db.ExecContext(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("delete kpi definition: %w", err)
+	}
+	return nil
+}
+
+func (r *KPIRepository) InsertSample(ctx context.Context, s *KPISample) error {
+	query := `
+		INSERT INTO kpi_samples (kpi_name, cluster_name, value, error_message, sampled_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.KPIName,
+		s.ClusterName,
+		s.Value,
+		s.ErrorMessage,
+		s.SampledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert kpi sample: %w", err)
+	}
+	return nil
+}
+
+func (r *KPIRepository) ListSamples(ctx context.Context, name string, since time.Time, limit int) ([]*KPISample, error) {
+	query := `
+		SELECT kpi_name, cluster_name, value, error_message, sampled_at
+		FROM kpi_samples
+		WHERE kpi_name = ? AND sampled_at >= ?
+		ORDER BY sampled_at DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, name, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list kpi samples: %w", err)
+	}
+	defer rows.Close()
+
+	var samples []*KPISample
+	for rows.Next() {
+		var s KPISample
+		err := rows.Scan(
+			&s.KPIName,
+			&s.ClusterName,
+			&s.Value,
+			&s.ErrorMessage,
+			&s.SampledAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan kpi sample: %w", err)
+		}
+		samples = append(samples, &s)
+	}
+	return samples, nil
+}
+
+func (r *KPIRepository) DeleteSamplesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM kpi_samples WHERE sampled_at < ?`
+	result, err := r.db.ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete kpi samples: %w", err)
+	}
+	return result.RowsAffected()
+}
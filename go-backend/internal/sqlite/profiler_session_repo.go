@@ -0,0 +1,154 @@
+/*
+AngelaMos | 2026
+profiler_session_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ProfilerSessionRepository struct {
+	db *sql.DB
+}
+
+func NewProfilerSessionRepository(client *Client) *ProfilerSessionRepository {
+	return &ProfilerSessionRepository{db: client.DB()}
+}
+
+type ProfilerSession struct {
+	ID                 string
+	ClusterName        string
+	DatabaseName       string
+	Level              int
+	SlowMs             int
+	SampleRate         float64
+	Filter             string
+	PreviousLevel      int
+	PreviousSlowMs     int
+	PreviousSampleRate float64
+	PreviousFilter     string
+	Status             string
+	StartedAt          time.Time
+	ExpiresAt          time.Time
+	EndedAt            sql.NullTime
+	ErrorMessage       sql.NullString
+}
+
+const profilerSessionColumns = `id, cluster_name, database_name, level, slow_ms, sample_rate, filter, previous_level, previous_slow_ms, previous_sample_rate, previous_filter, status, started_at, expires_at, ended_at, error_message`
+
+func (r *ProfilerSessionRepository) Create(ctx context.Context, s *ProfilerSession) error {
+	query := `
+		INSERT INTO profiler_sessions (` + profilerSessionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID,
+		s.ClusterName,
+		s.DatabaseName,
+		s.Level,
+		s.SlowMs,
+		s.SampleRate,
+		s.Filter,
+		s.PreviousLevel,
+		s.PreviousSlowMs,
+		s.PreviousSampleRate,
+		s.PreviousFilter,
+		s.Status,
+		s.StartedAt,
+		s.ExpiresAt,
+		s.EndedAt,
+		s.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("insert profiler session: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfilerSessionRepository) Finish(ctx context.Context, id, status string, endedAt time.Time, errorMsg string) error {
+	query := `UPDATE profiler_sessions SET status = ?, ended_at = ?, error_message = ? WHERE id = ?`
+
+	errMsgNull := sql.NullString{String: errorMsg, Valid: errorMsg != ""}
+
+	_, err := r.db.ExecContext(ctx, query, status, endedAt, errMsgNull, id)
+	if err != nil {
+		return fmt.Errorf("finish profiler session: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfilerSessionRepository) GetByID(ctx context.Context, id string) (*ProfilerSession, error) {
+	query := `SELECT ` + profilerSessionColumns + ` FROM profiler_sessions WHERE id = ?`
+
+	s, err := scanProfilerSession(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get profiler session: %w", err)
+	}
+	return s, nil
+}
+
+func (r *ProfilerSessionRepository) ListRecent(ctx context.Context, limit int) ([]*ProfilerSession, error) {
+	query := `SELECT ` + profilerSessionColumns + ` FROM profiler_sessions ORDER BY started_at DESC LIMIT ?`
+	return r.list(ctx, query, limit)
+}
+
+func (r *ProfilerSessionRepository) ListByStatus(ctx context.Context, status string) ([]*ProfilerSession, error) {
+	query := `SELECT ` + profilerSessionColumns + ` FROM profiler_sessions WHERE status = ? ORDER BY started_at`
+	return r.list(ctx, query, status)
+}
+
+func (r *ProfilerSessionRepository) list(ctx context.Context, query string, args ...any) ([]*ProfilerSession, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list profiler sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*ProfilerSession
+	for rows.Next() {
+		s, err := scanProfilerSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan profiler session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProfilerSession(row rowScanner) (*ProfilerSession, error) {
+	var s ProfilerSession
+	err := row.Scan(
+		&s.ID,
+		&s.ClusterName,
+		&s.DatabaseName,
+		&s.Level,
+		&s.SlowMs,
+		&s.SampleRate,
+		&s.Filter,
+		&s.PreviousLevel,
+		&s.PreviousSlowMs,
+		&s.PreviousSampleRate,
+		&s.PreviousFilter,
+		&s.Status,
+		&s.StartedAt,
+		&s.ExpiresAt,
+		&s.EndedAt,
+		&s.ErrorMessage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
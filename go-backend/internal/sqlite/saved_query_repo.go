@@ -0,0 +1,230 @@
+/*
+AngelaMos | 2026
+saved_query_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type SavedQueryRepository struct {
+	db *sql.DB
+}
+
+func NewSavedQueryRepository(client *Client) *SavedQueryRepository {
+	return &SavedQueryRepository{db: client.DB()}
+}
+
+type SavedQuery struct {
+	ID              string
+	Name            string
+	Description     string
+	ClusterName     string
+	DatabaseName    string
+	CollectionName  string
+	Kind            string
+	Filter          string
+	Sort            string
+	Projection      string
+	Pipeline        string
+	Parameters      string
+	Tags            string
+	CacheTTLSeconds int64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type SavedQueryFilter struct {
+	ClusterName    string
+	DatabaseName   string
+	CollectionName string
+	Tag            string
+}
+
+type SavedQueryTag struct {
+	Tag   string
+	Count int64
+}
+
+const savedQueryColumns = `id, name, description, cluster_name, database_name, collection_name, kind, filter, sort, projection, pipeline, parameters, tags, cache_ttl_seconds, created_at, updated_at`
+
+func (r *SavedQueryRepository) Create(ctx context.Context, q *SavedQuery) error {
+	query := `
+		INSERT INTO saved_queries (` + savedQueryColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		q.ID,
+		q.Name,
+		q.Description,
+		q.ClusterName,
+		q.DatabaseName,
+		q.CollectionName,
+		q.Kind,
+		q.Filter,
+		q.Sort,
+		q.Projection,
+		q.Pipeline,
+		q.Parameters,
+		q.Tags,
+		q.CacheTTLSeconds,
+		q.CreatedAt,
+		q.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert saved query: %w", err)
+	}
+	return nil
+}
+
+func (r *SavedQueryRepository) Update(ctx context.Context, q *SavedQuery) error {
+	query := `
+		UPDATE saved_queries
+		SET name = ?, description = ?, cluster_name = ?, database_name = ?, collection_name = ?, kind = ?,
+			filter = ?, sort = ?, projection = ?, pipeline = ?, parameters = ?, tags = ?, cache_ttl_seconds = ?, updated_at = ?
+		WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query,
+		q.Name,
+		q.Description,
+		q.ClusterName,
+		q.DatabaseName,
+		q.CollectionName,
+		q.Kind,
+		q.Filter,
+		q.Sort,
+		q.Projection,
+		q.Pipeline,
+		q.Parameters,
+		q.Tags,
+		q.CacheTTLSeconds,
+		q.UpdatedAt,
+		q.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update saved query: %w", err)
+	}
+	return nil
+}
+
+func (r *SavedQueryRepository) GetByID(ctx context.Context, id string) (*SavedQuery, error) {
+	query := `SELECT ` + savedQueryColumns + ` FROM saved_queries WHERE id = ?`
+
+	q, err := scanSavedQuery(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get saved query: %w", err)
+	}
+	return q, nil
+}
+
+func (r *SavedQueryRepository) GetByName(ctx context.Context, clusterName, dbName, collName, name string) (*SavedQuery, error) {
+	query := `
+		SELECT ` + savedQueryColumns + ` FROM saved_queries
+		WHERE cluster_name = ? AND database_name = ? AND collection_name = ? AND name = ?`
+
+	q, err := scanSavedQuery(r.db.QueryRowContext(ctx, query, clusterName, dbName, collName, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get saved query by name: %w", err)
+	}
+	return q, nil
+}
+
+func (r *SavedQueryRepository) List(ctx context.Context, f SavedQueryFilter) ([]*SavedQuery, error) {
+	query := `
+		SELECT ` + savedQueryColumns + ` FROM saved_queries
+		WHERE (? = '' OR cluster_name = ?)
+			AND (? = '' OR database_name = ?)
+			AND (? = '' OR collection_name = ?)
+			AND (? = '' OR EXISTS (SELECT 1 FROM json_each(saved_queries.tags) WHERE json_each.value = ?))
+		ORDER BY database_name, collection_name, name`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		f.ClusterName, f.ClusterName,
+		f.DatabaseName, f.DatabaseName,
+		f.CollectionName, f.CollectionName,
+		f.Tag, f.Tag,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list saved queries: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []*SavedQuery
+	for rows.Next() {
+		q, err := scanSavedQuery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan saved query: %w", err)
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+func (r *SavedQueryRepository) ListTags(ctx context.Context) ([]SavedQueryTag, error) {
+	query := `
+		SELECT json_each.value, COUNT(*)
+		FROM saved_queries, json_each(saved_queries.tags)
+		GROUP BY json_each.value
+		ORDER BY json_each.value`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list saved query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []SavedQueryTag
+	for rows.Next() {
+		var t SavedQueryTag
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			return nil, fmt.Errorf("scan saved query tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+func (r *SavedQueryRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM saved_queries WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete saved query: %w", err)
+	}
+	return nil
+}
+
+func scanSavedQuery(row rowScanner) (*SavedQuery, error) {
+	var q SavedQuery
+	err := row.Scan(
+		&q.ID,
+		&q.Name,
+		&q.Description,
+		&q.ClusterName,
+		&q.DatabaseName,
+		&q.CollectionName,
+		&q.Kind,
+		&q.Filter,
+		&q.Sort,
+		&q.Projection,
+		&q.Pipeline,
+		&q.Parameters,
+		&q.Tags,
+		&q.CacheTTLSeconds,
+		&q.CreatedAt,
+		&q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
@@ -0,0 +1,172 @@
+/*
+AngelaMos | 2026
+schema_snapshot_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type SchemaSnapshotRepository struct {
+	db *sql.DB
+}
+
+func NewSchemaSnapshotRepository(client *Client) *SchemaSnapshotRepository {
+	return &SchemaSnapshotRepository{db: client.DB()}
+}
+
+type SchemaSnapshot struct {
+	ID             string
+	ClusterName    string
+	DatabaseName   string
+	CollectionName string
+	SampleSize     int64
+	TotalDocuments int64
+	FieldCount     int
+	Fields         string
+	PreviousID     sql.NullString
+	Drift          sql.NullString
+	ChangeCount    int
+	CreatedAt      time.Time
+}
+
+type SchemaSnapshotFilter struct {
+	ClusterName    string
+	DatabaseName   string
+	CollectionName string
+	DriftOnly      bool
+	Limit          int
+}
+
+const schemaSnapshotColumns = `id, cluster_name, database_name, collection_name, sample_size, total_documents, field_count, fields, previous_id, drift, change_count, created_at`
+
+func (r *SchemaSnapshotRepository) Create(ctx context.Context, s *SchemaSnapshot) error {
+	query := `
+		INSERT INTO schema_snapshots (` + schemaSnapshotColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID,
+		s.ClusterName,
+		s.DatabaseName,
+		s.CollectionName,
+		s.SampleSize,
+		s.TotalDocuments,
+		s.FieldCount,
+		s.Fields,
+		s.PreviousID,
+		s.Drift,
+		s.ChangeCount,
+		s.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert schema snapshot: %w", err)
+	}
+	return nil
+}
+
+func (r *SchemaSnapshotRepository) GetByID(ctx context.Context, id string) (*SchemaSnapshot, error) {
+	query := `SELECT ` + schemaSnapshotColumns + ` FROM schema_snapshots WHERE id = ?`
+
+	s, err := scanSchemaSnapshot(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get schema snapshot: %w", err)
+	}
+	return s, nil
+}
+
+func (r *SchemaSnapshotRepository) Latest(ctx context.Context, clusterName, dbName, collName string) (*SchemaSnapshot, error) {
+	query := `
+		SELECT ` + schemaSnapshotColumns + ` FROM schema_snapshots
+		WHERE cluster_name = ? AND database_name = ? AND collection_name = ?
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	s, err := scanSchemaSnapshot(r.db.QueryRowContext(ctx, query, clusterName, dbName, collName))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get latest schema snapshot: %w", err)
+	}
+	return s, nil
+}
+
+func (r *SchemaSnapshotRepository) List(ctx context.Context, f SchemaSnapshotFilter) ([]*SchemaSnapshot, error) {
+	query := `
+		SELECT ` + schemaSnapshotColumns + ` FROM schema_snapshots
+		WHERE (? = '' OR cluster_name = ?)
+			AND (? = '' OR database_name = ?)
+			AND (? = '' OR collection_name = ?)
+			AND (? = 0 OR change_count > 0)
+		ORDER BY created_at DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		f.ClusterName, f.ClusterName,
+		f.DatabaseName, f.DatabaseName,
+		f.CollectionName, f.CollectionName,
+		f.DriftOnly,
+		f.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list schema snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*SchemaSnapshot
+	for rows.Next() {
+		s, err := scanSchemaSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schema snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, nil
+}
+
+func (r *SchemaSnapshotRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schema_snapshots WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete schema snapshot: %w", err)
+	}
+	return nil
+}
+
+func (r *SchemaSnapshotRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM schema_snapshots WHERE created_at < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete schema snapshots: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanSchemaSnapshot(row rowScanner) (*SchemaSnapshot, error) {
+	var s SchemaSnapshot
+	err := row.Scan(
+		&s.ID,
+		&s.ClusterName,
+		&s.DatabaseName,
+		&s.CollectionName,
+		&s.SampleSize,
+		&s.TotalDocuments,
+		&s.FieldCount,
+		&s.Fields,
+		&s.PreviousID,
+		&s.Drift,
+		&s.ChangeCount,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,284 @@
+/*
+AngelaMos | 2026
+slow_query_repo.go
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type SlowQueryRepository struct {
+	db *sql.DB
+}
+
+func NewSlowQueryRepository(client *Client) *SlowQueryRepository {
+	return &SlowQueryRepository{db: client.DB()}
+}
+
+type StoredSlowQuery struct {
+	ID             int64
+	EntryHash      string
+	ClusterName    string
+	DatabaseName   string
+	Namespace      string
+	Op             string
+	Millis         int64
+	PlanSummary    string
+	KeysExamined   int64
+	DocsExamined   int64
+	NReturned      int64
+	NumYields      int64
+	ResponseLength int64
+	HasSortStage   bool
+	QueryHash      string
+	Client         string
+	User           string
+	AppName        string
+	Command        string
+	Timestamp      time.Time
+	CollectedAt    time.Time
+}
+
+type SlowQueryFilter struct {
+	ClusterName  string
+	DatabaseName string
+	Namespace    string
+	Op           string
+	PlanSummary  string
+	From         time.Time
+	To           time.Time
+	MinMillis    int64
+	SortBy       string
+	Limit        int
+	Offset       int
+}
+
+const slowQueryColumns = `id, entry_hash, cluster_name, database_name, namespace, op, millis, plan_summary, keys_examined, docs_examined, nreturned, num_yields, response_length, has_sort_stage, query_hash, client, user_name, app_name, command, ts, collected_at`
+
+func (r *SlowQueryRepository) Insert(ctx context.Context, q *StoredSlowQuery) (bool, error) {
+	query := `
+		INSERT OR IGNORE INTO slow_queries (entry_hash, cluster_name, database_name, namespace, op, millis, plan_summary, keys_examined, docs_examined, nreturned, num_yields, response_length, has_sort_stage, query_hash, client, user_name, app_name, command, ts, collected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		q.EntryHash,
+		q.ClusterName,
+		q.DatabaseName,
+		q.Namespace,
+		q.Op,
+		q.Millis,
+		q.PlanSummary,
+		q.KeysExamined,
+		q.DocsExamined,
+		q.NReturned,
+		q.NumYields,
+		q.ResponseLength,
+		q.HasSortStage,
+		q.QueryHash,
+		q.Client,
+		q.User,
+		q.AppName,
+		q.Command,
+		q.Timestamp,
+		q.CollectedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert slow query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("insert slow query: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *SlowQueryRepository) LatestTimestamp(ctx context.Context, clusterName, dbName string) (time.Time, error) {
+	query := `SELECT ts FROM slow_queries WHERE cluster_name = ? AND database_name = ? ORDER BY ts DESC LIMIT 1`
+
+	var ts time.Time
+	err := r.db.QueryRowContext(ctx, query, clusterName, dbName).Scan(&ts)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("latest slow query timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+func (r *SlowQueryRepository) List(ctx context.Context, filter SlowQueryFilter) ([]*StoredSlowQuery, int, error) {
+	where, args := slowQueryWhere(filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM slow_queries` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count slow queries: %w", err)
+	}
+
+	orderBy := ` ORDER BY ts DESC`
+	if filter.SortBy == "duration" {
+		orderBy = ` ORDER BY millis DESC, ts DESC`
+	}
+
+	query := `
+		SELECT ` + slowQueryColumns + `
+		FROM slow_queries` + where + orderBy + ` LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list slow queries: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []*StoredSlowQuery
+	for rows.Next() {
+		q, err := scanSlowQuery(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan slow query: %w", err)
+		}
+		queries = append(queries, q)
+	}
+	return queries, total, nil
+}
+
+func (r *SlowQueryRepository) GetByTimestamp(ctx context.Context, clusterName, namespace string, ts time.Time) (*StoredSlowQuery, error) {
+	query := `
+		SELECT ` + slowQueryColumns + `
+		FROM slow_queries
+		WHERE cluster_name = ? AND namespace = ? AND ts = ?
+		ORDER BY id LIMIT 1`
+
+	q, err := scanSlowQuery(r.db.QueryRowContext(ctx, query, clusterName, namespace, ts.UTC()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get slow query: %w", err)
+	}
+	return q, nil
+}
+
+func (r *SlowQueryRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM slow_queries WHERE ts < ?`
+	result, err := r.db.ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete slow queries: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func slowQueryWhere(filter SlowQueryFilter) (string, []any) {
+	var clauses []string
+	var args []any
+
+	if filter.ClusterName != "" {
+		clauses = append(clauses, "cluster_name = ?")
+		args = append(args, filter.ClusterName)
+	}
+	if filter.DatabaseName != "" {
+		clauses = append(clauses, "database_name = ?")
+		args = append(args, filter.DatabaseName)
+	}
+	if filter.Namespace != "" {
+		clauses = append(clauses, "namespace = ?")
+		args = append(args, filter.Namespace)
+	}
+	if filter.Op != "" {
+		clauses = append(clauses, "op = ?")
+		args = append(args, filter.Op)
+	}
+	if filter.PlanSummary != "" {
+		clauses = append(clauses, "plan_summary LIKE ?")
+		args = append(args, "%"+filter.PlanSummary+"%")
+	}
+	if !filter.From.IsZero() {
+		clauses = append(clauses, "ts >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		clauses = append(clauses, "ts <= ?")
+		args = append(args, filter.To)
+	}
+	if filter.MinMillis > 0 {
+		clauses = append(clauses, "millis >= ?")
+		args = append(args, filter.MinMillis)
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+type SlowQuerySummary struct {
+	Namespace   string
+	Op          string
+	Count       int
+	TotalMillis int64
+	MaxMillis   int64
+	AvgMillis   float64
+}
+
+func (r *SlowQueryRepository) Summarize(ctx context.Context, filter SlowQueryFilter) ([]*SlowQuerySummary, error) {
+	where, args := slowQueryWhere(filter)
+
+	query := `
+		SELECT namespace, op, COUNT(*), SUM(millis), MAX(millis), AVG(millis)
+		FROM slow_queries` + where + `
+		GROUP BY namespace, op
+		ORDER BY SUM(millis) DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("summarize slow queries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*SlowQuerySummary
+	for rows.Next() {
+		var s SlowQuerySummary
+		if err := rows.Scan(&s.Namespace, &s.Op, &s.Count, &s.TotalMillis, &s.MaxMillis, &s.AvgMillis); err != nil {
+			return nil, fmt.Errorf("scan slow query summary: %w", err)
+		}
+		summaries = append(summaries, &s)
+	}
+	return summaries, nil
+}
+
+func scanSlowQuery(row rowScanner) (*StoredSlowQuery, error) {
+	var q StoredSlowQuery
+	err := row.Scan(
+		&q.ID,
+		&q.EntryHash,
+		&q.ClusterName,
+		&q.DatabaseName,
+		&q.Namespace,
+		&q.Op,
+		&q.Millis,
+		&q.PlanSummary,
+		&q.KeysExamined,
+		&q.DocsExamined,
+		&q.NReturned,
+		&q.NumYields,
+		&q.ResponseLength,
+		&q.HasSortStage,
+		&q.QueryHash,
+		&q.Client,
+		&q.User,
+		&q.AppName,
+		&q.Command,
+		&q.Timestamp,
+		&q.CollectedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
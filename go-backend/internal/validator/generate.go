@@ -0,0 +1,204 @@
+/*
+AngelaMos | 2026
+generate.go
+*/
+
+package validator
+
+import (
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+)
+
+const (
+	enumMinValues      = 2
+	enumMinOccurrences = 3
+)
+
+var enumTypes = map[string]bool{
+	"string": true,
+	"int":    true,
+	"long":   true,
+	"null":   true,
+}
+
+type generateOptions struct {
+	requiredThreshold float64
+	enumMaxValues     int
+}
+
+type schemaNode struct {
+	field    *mongodb.FieldSchema
+	children map[string]*schemaNode
+	items    *schemaNode
+}
+
+type generator struct {
+	opts     generateOptions
+	required []string
+	enums    []string
+}
+
+func newNode() *schemaNode {
+	return &schemaNode{children: make(map[string]*schemaNode)}
+}
+
+func buildTree(fields []mongodb.FieldSchema) *schemaNode {
+	root := newNode()
+	for i := range fields {
+		node := root
+		for _, segment := range strings.Split(fields[i].Name, ".") {
+			if segment == mongodb.ArrayPathSegment {
+				if node.items == nil {
+					node.items = newNode()
+				}
+				node = node.items
+				continue
+			}
+			child, ok := node.children[segment]
+			if !ok {
+				child = newNode()
+				node.children[segment] = child
+			}
+			node = child
+		}
+		node.field = &fields[i]
+	}
+	return root
+}
+
+func (g *generator) generate(analysis *mongodb.SchemaAnalysis) bson.D {
+	root := buildTree(analysis.Fields)
+
+	schema := bson.D{{Key: "bsonType", Value: "object"}}
+	schema = append(schema, g.objectSchema("", root, analysis.SampleSize)...)
+
+	return bson.D{{Key: "$jsonSchema", Value: schema}}
+}
+
+func (g *generator) objectSchema(path string, node *schemaNode, objects int64) bson.D {
+	names := make([]string, 0, len(node.children))
+	for name, child := range node.children {
+		if child.field != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	properties := bson.D{}
+	required := bson.A{}
+	for _, name := range names {
+		child := node.children[name]
+		childPath := joinPath(path, name)
+
+		properties = append(properties, bson.E{Key: name, Value: g.fieldSchema(childPath, child)})
+		if objects > 0 && float64(child.field.Occurrences)/float64(objects)*100 >= g.opts.requiredThreshold {
+			required = append(required, name)
+			g.required = append(g.required, childPath)
+		}
+	}
+
+	out := bson.D{}
+	if len(required) > 0 {
+		out = append(out, bson.E{Key: "required", Value: required})
+	}
+	return append(out, bson.E{Key: "properties", Value: properties})
+}
+
+func (g *generator) fieldSchema(path string, node *schemaNode) bson.D {
+	field := node.field
+
+	schema := bson.D{{Key: "bsonType", Value: bsonTypes(field.Types)}}
+
+	if objects := typeCount(field, "object"); objects > 0 {
+		schema = append(schema, g.objectSchema(path, node, objects)...)
+	}
+	if typeCount(field, "array") > 0 && node.items != nil && node.items.field != nil {
+		schema = append(schema, bson.E{Key: "items", Value: g.fieldSchema(path+"."+mongodb.ArrayPathSegment, node.items)})
+	}
+	if values := g.enumValues(path, field); values != nil {
+		schema = append(schema, bson.E{Key: "enum", Value: values})
+	}
+
+	return schema
+}
+
+func (g *generator) enumValues(path string, field *mongodb.FieldSchema) bson.A {
+	if g.opts.enumMaxValues <= 0 || path == "_id" {
+		return nil
+	}
+	if len(field.Distinct) < enumMinValues || len(field.Distinct) > g.opts.enumMaxValues {
+		return nil
+	}
+
+	nulls := typeCount(field, "null")
+	for _, t := range field.Types {
+		if !enumTypes[t] {
+			return nil
+		}
+	}
+	if field.Occurrences-nulls < int64(len(field.Distinct))*enumMinOccurrences {
+		return nil
+	}
+
+	distinct := append([]bson.RawValue(nil), field.Distinct...)
+	sort.SliceStable(distinct, func(i, j int) bool {
+		a, b := distinct[i], distinct[j]
+		if a.Type == bson.TypeString && b.Type == bson.TypeString {
+			return a.StringValue() < b.StringValue()
+		}
+		if a.Type == bson.TypeString || b.Type == bson.TypeString {
+			return b.Type == bson.TypeString
+		}
+		return a.AsInt64() < b.AsInt64()
+	})
+
+	values := make(bson.A, 0, len(distinct)+1)
+	for _, v := range distinct {
+		values = append(values, v)
+	}
+	if nulls > 0 {
+		values = append(values, nil)
+	}
+
+	g.enums = append(g.enums, path)
+	return values
+}
+
+func bsonTypes(types []string) any {
+	if len(types) == 1 {
+		return types[0]
+	}
+
+	sorted := append([]string(nil), types...)
+	sort.Strings(sorted)
+
+	out := make(bson.A, 0, len(sorted))
+	for _, t := range sorted {
+		out = append(out, t)
+	}
+	return out
+}
+
+func typeCount(field *mongodb.FieldSchema, bsonType string) int64 {
+	for _, tc := range field.TypeCounts {
+		if tc.Type == bsonType {
+			return tc.Count
+		}
+	}
+	return 0
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
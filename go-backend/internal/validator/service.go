@@ -0,0 +1,441 @@
+/*
+AngelaMos | 2026
+service.go
+*/
+
+package validator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github.com/carterperez-dev/templates/go-backend/internal/config"
+	"github.com/carterperez-dev/templates/go-backend/internal/core"
+	"github.com/carterperez-dev/templates/go-backend/internal/mongodb"
+	"github.com/carterperez-dev/templates/go-backend/internal/sqlite"
+)
+
+const (
+	ActionApply = "collection.validator"
+
+	DefaultSampleSize        = 1000
+	MaxSampleSize            = 10000
+	DefaultRequiredThreshold = 100
+	DefaultEnumMaxValues     = 10
+	DefaultReportSamples     = 10
+	MaxReportSamples         = 100
+
+	confirmTokenTTL = 2 * time.Minute
+)
+
+var (
+	validationLevels  = map[string]bool{"off": true, "strict": true, "moderate": true}
+	validationActions = map[string]bool{"error": true, "warn": true, "errorAndLog": true}
+	enforcingActions  = map[string]bool{"error": true, "errorAndLog": true}
+)
+
+type collectionsRepository interface {
+	AnalyzeSchema(ctx context.Context, dbName, collName string, sampleSize int) (*mongodb.SchemaAnalysis, error)
+	GetValidator(ctx context.Context, dbName, collName string) (*mongodb.ValidatorInfo, error)
+	SetValidator(ctx context.Context, dbName, collName string, validator bson.D, level, action string) error
+	CountDocuments(ctx context.Context, dbName, collName string, filter bson.D) (int64, error)
+	FindDocuments(ctx context.Context, dbName, collName string, query mongodb.DocumentQuery) ([]bson.Raw, error)
+}
+
+type auditRepository interface {
+	Create(ctx context.Context, e *sqlite.AuditEntry) error
+}
+
+type Service struct {
+	repo     collectionsRepository
+	audit    auditRepository
+	readOnly bool
+	tokens   map[string]confirmToken
+	mu       sync.Mutex
+	logger   *slog.Logger
+}
+
+type confirmToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewService(repo collectionsRepository, audit auditRepository, cfg config.DocumentsConfig, logger *slog.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		audit:    audit,
+		readOnly: cfg.ReadOnly,
+		tokens:   make(map[string]confirmToken),
+		logger:   logger,
+	}
+}
+
+type Actor struct {
+	RequestID  string
+	RemoteAddr string
+}
+
+type Validator struct {
+	Collection       string          `json:"collection"`
+	Validator        json.RawMessage `json:"validator"`
+	ValidationLevel  string          `json:"validation_level"`
+	ValidationAction string          `json:"validation_action"`
+	ConfirmToken     string          `json:"confirm_token"`
+	ConfirmExpiresAt time.Time       `json:"confirm_expires_at"`
+}
+
+type GenerateInput struct {
+	SampleSize        int
+	RequiredThreshold float64
+	EnumMaxValues     int
+	Format            string
+}
+
+type Generated struct {
+	Collection        string          `json:"collection"`
+	SampleSize        int64           `json:"sample_size"`
+	RequiredThreshold float64         `json:"required_threshold"`
+	EnumMaxValues     int             `json:"enum_max_values"`
+	Validator         json.RawMessage `json:"validator"`
+	RequiredFields    []string        `json:"required_fields"`
+	EnumFields        []string        `json:"enum_fields"`
+}
+
+type ApplyInput struct {
+	Validator        json.RawMessage
+	ValidationLevel  string
+	ValidationAction string
+	Format           string
+	ConfirmToken     string
+}
+
+type ReportInput struct {
+	Validator json.RawMessage
+	Samples   int
+	Format    string
+}
+
+type Report struct {
+	Collection        string            `json:"collection"`
+	Validator         json.RawMessage   `json:"validator"`
+	TotalDocuments    int64             `json:"total_documents"`
+	Failing           int64             `json:"failing"`
+	Passing           int64             `json:"passing"`
+	FailingPercentage float64           `json:"failing_percentage"`
+	FailingIDs        []json.RawMessage `json:"failing_ids"`
+	DurationMs        int64             `json:"duration_ms"`
+}
+
+func (s *Service) Get(ctx context.Context, dbName, collName, format string) (*Validator, error) {
+	info, err := s.current(ctx, dbName, collName)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.issueToken(tokenKey(ctx, dbName, collName))
+	if err != nil {
+		return nil, fmt.Errorf("issue confirm token: %w", err)
+	}
+
+	v := validatorView(collName, info, format)
+	v.ConfirmToken = token.value
+	v.ConfirmExpiresAt = token.expiresAt
+	return v, nil
+}
+
+func (s *Service) Generate(ctx context.Context, dbName, collName string, input GenerateInput) (*Generated, error) {
+	if input.SampleSize <= 0 {
+		input.SampleSize = DefaultSampleSize
+	}
+	if input.SampleSize > MaxSampleSize {
+		return nil, core.ValidationError(fmt.Sprintf("sample_size cannot exceed %d", MaxSampleSize))
+	}
+	if input.RequiredThreshold == 0 {
+		input.RequiredThreshold = DefaultRequiredThreshold
+	}
+	if input.RequiredThreshold < 0 || input.RequiredThreshold > 100 {
+		return nil, core.ValidationError("required_threshold must be a percentage between 0 and 100")
+	}
+	if input.EnumMaxValues < 0 || input.EnumMaxValues > mongodb.MaxDistinctValues {
+		return nil, core.ValidationError(fmt.Sprintf("enum_max_values must be between 0 and %d", mongodb.MaxDistinctValues))
+	}
+
+	analysis, err := s.repo.AnalyzeSchema(ctx, dbName, collName, input.SampleSize)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	if analysis.SampleSize == 0 {
+		return nil, core.ValidationError("collection has no documents to infer a validator from")
+	}
+
+	g := &generator{opts: generateOptions{
+		requiredThreshold: input.RequiredThreshold,
+		enumMaxValues:     input.EnumMaxValues,
+	}}
+	validator, err := bson.Marshal(g.generate(analysis))
+	if err != nil {
+		return nil, fmt.Errorf("encode validator: %w", err)
+	}
+
+	result := &Generated{
+		Collection:        collName,
+		SampleSize:        analysis.SampleSize,
+		RequiredThreshold: input.RequiredThreshold,
+		EnumMaxValues:     input.EnumMaxValues,
+		Validator:         mongodb.EncodeExtJSON(validator, input.Format),
+		RequiredFields:    g.required,
+		EnumFields:        g.enums,
+	}
+	if result.RequiredFields == nil {
+		result.RequiredFields = []string{}
+	}
+	if result.EnumFields == nil {
+		result.EnumFields = []string{}
+	}
+
+	return result, nil
+}
+
+func (s *Service) Apply(ctx context.Context, dbName, collName string, input ApplyInput, actor Actor) (*Validator, error) {
+	if s.readOnly {
+		return nil, core.ForbiddenError("validator changes are disabled in read-only mode")
+	}
+
+	if input.ValidationLevel == "" {
+		input.ValidationLevel = "strict"
+	}
+	if input.ValidationAction == "" {
+		input.ValidationAction = "warn"
+	}
+	if !validationLevels[input.ValidationLevel] {
+		return nil, core.ValidationError("validation_level must be off, strict or moderate")
+	}
+	if !validationActions[input.ValidationAction] {
+		return nil, core.ValidationError("validation_action must be error, warn or errorAndLog")
+	}
+
+	if len(input.Validator) == 0 {
+		return nil, core.ValidationError("validator is required; send {} to remove the current validator")
+	}
+	validator, err := mongodb.ParseExtJSONDocument(input.Validator)
+	if err != nil {
+		return nil, core.ValidationError("validator must be an extended JSON document")
+	}
+
+	if enforcingActions[input.ValidationAction] && !s.consumeToken(tokenKey(ctx, dbName, collName), input.ConfirmToken) {
+		return nil, core.ForbiddenError("invalid or expired confirmation token")
+	}
+
+	before, err := s.current(ctx, dbName, collName)
+	if err != nil {
+		return nil, err
+	}
+
+	modErr := s.repo.SetValidator(ctx, dbName, collName, validator, input.ValidationLevel, input.ValidationAction)
+	s.record(ctx, dbName, collName, before, validator, input, actor, modErr)
+	if modErr != nil {
+		return nil, commandError(modErr)
+	}
+
+	return s.Get(ctx, dbName, collName, input.Format)
+}
+
+func (s *Service) Report(ctx context.Context, dbName, collName string, input ReportInput) (*Report, error) {
+	if input.Samples == 0 {
+		input.Samples = DefaultReportSamples
+	}
+	if input.Samples < 0 || input.Samples > MaxReportSamples {
+		return nil, core.ValidationError(fmt.Sprintf("samples must be between 0 and %d", MaxReportSamples))
+	}
+
+	var validator bson.Raw
+	if len(input.Validator) > 0 && string(input.Validator) != "null" {
+		doc, err := mongodb.ParseExtJSONDocument(input.Validator)
+		if err != nil {
+			return nil, core.ValidationError("validator must be an extended JSON document")
+		}
+		validator, err = bson.Marshal(doc)
+		if err != nil {
+			return nil, core.ValidationError("validator must be an extended JSON document")
+		}
+	} else {
+		info, err := s.current(ctx, dbName, collName)
+		if err != nil {
+			return nil, err
+		}
+		validator = info.Validator
+	}
+	if elems, _ := validator.Elements(); len(elems) == 0 {
+		return nil, core.ValidationError("collection has no validator; supply one to report against")
+	}
+
+	start := time.Now()
+	filter := bson.D{{Key: "$nor", Value: bson.A{validator}}}
+
+	total, err := s.repo.CountDocuments(ctx, dbName, collName, nil)
+	if err != nil {
+		return nil, commandError(err)
+	}
+	failing, err := s.repo.CountDocuments(ctx, dbName, collName, filter)
+	if err != nil {
+		return nil, commandError(err)
+	}
+
+	report := &Report{
+		Collection:     collName,
+		Validator:      mongodb.EncodeExtJSON(validator, input.Format),
+		TotalDocuments: total,
+		Failing:        failing,
+		Passing:        max(total-failing, 0),
+		FailingIDs:     []json.RawMessage{},
+	}
+	if total > 0 {
+		report.FailingPercentage = float64(failing) / float64(total) * 100
+	}
+
+	if failing > 0 && input.Samples > 0 {
+		docs, err := s.repo.FindDocuments(ctx, dbName, collName, mongodb.DocumentQuery{
+			Filter:     filter,
+			Projection: bson.D{{Key: "_id", Value: 1}},
+			Limit:      int64(input.Samples),
+		})
+		if err != nil {
+			return nil, commandError(err)
+		}
+		for _, doc := range docs {
+			report.FailingIDs = append(report.FailingIDs, mongodb.EncodeExtJSONValue(doc.Lookup("_id"), input.Format))
+		}
+	}
+
+	report.DurationMs = time.Since(start).Milliseconds()
+	return report, nil
+}
+
+func (s *Service) current(ctx context.Context, dbName, collName string) (*mongodb.ValidatorInfo, error) {
+	info, err := s.repo.GetValidator(ctx, dbName, collName)
+	if errors.Is(err, mongodb.ErrCollectionNotFound) {
+		return nil, core.NotFoundError("collection")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *Service) record(ctx context.Context, dbName, collName string, before *mongodb.ValidatorInfo, validator bson.D, input ApplyInput, actor Actor, opErr error) {
+	after, _ := bson.Marshal(validator)
+	payload, _ := json.Marshal(map[string]any{
+		"collection":               collName,
+		"before":                   mongodb.EncodeExtJSON(before.Validator, mongodb.ExtJSONCanonical),
+		"before_validation_level":  before.ValidationLevel,
+		"before_validation_action": before.ValidationAction,
+		"after":                    mongodb.EncodeExtJSON(after, mongodb.ExtJSONCanonical),
+		"validation_level":         input.ValidationLevel,
+		"validation_action":        input.ValidationAction,
+	})
+
+	entry := &sqlite.AuditEntry{
+		ID:           uuid.New().String(),
+		Action:       ActionApply,
+		Resource:     "collection",
+		ResourceID:   dbName + "." + collName,
+		ClusterName:  mongodb.ClusterName(ctx),
+		DatabaseName: dbName,
+		Details:      sql.NullString{String: string(payload), Valid: len(payload) > 0},
+		RequestID:    actor.RequestID,
+		RemoteAddr:   actor.RemoteAddr,
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+	}
+	if opErr != nil {
+		entry.Status = "failed"
+		entry.ErrorMessage = sql.NullString{String: opErr.Error(), Valid: true}
+	}
+
+	if err := s.audit.Create(ctx, entry); err != nil {
+		s.logger.Error("failed to write audit entry", "action", ActionApply, "error", err)
+	}
+}
+
+func (s *Service) issueToken(key string) (confirmToken, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return confirmToken{}, err
+	}
+
+	token := confirmToken{
+		value:     hex.EncodeToString(buf),
+		expiresAt: time.Now().Add(confirmTokenTTL),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range s.tokens {
+		if now.After(t.expiresAt) {
+			delete(s.tokens, k)
+		}
+	}
+	s.tokens[key] = token
+
+	return token, nil
+}
+
+func (s *Service) consumeToken(key, value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	if !ok || value == "" {
+		return false
+	}
+	if time.Now().After(token.expiresAt) {
+		delete(s.tokens, key)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(token.value), []byte(value)) != 1 {
+		return false
+	}
+
+	delete(s.tokens, key)
+	return true
+}
+
+func tokenKey(ctx context.Context, dbName, collName string) string {
+	return mongodb.ClusterName(ctx) + "/" + dbName + "." + collName
+}
+
+func validatorView(collName string, info *mongodb.ValidatorInfo, format string) *Validator {
+	v := &Validator{
+		Collection:       collName,
+		Validator:        json.RawMessage("null"),
+		ValidationLevel:  info.ValidationLevel,
+		ValidationAction: info.ValidationAction,
+	}
+	if len(info.Validator) > 0 {
+		v.Validator = mongodb.EncodeExtJSON(info.Validator, format)
+	}
+	return v
+}
+
+func commandError(err error) error {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return core.ValidationError(cmdErr.Message)
+	}
+	return err
+}
@@ -43,6 +43,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		conn:     conn,
 		send:     make(chan []byte, 256),
 		clientID: clientID,
+		sub: Subscription{
+			Cluster:  r.URL.Query().Get("cluster"),
+			Database: r.URL.Query().Get("database"),
+		},
 	}
 
 	h.hub.register <- client
@@ -60,14 +64,28 @@ func (h *Handler) GetHub() *Hub {
 
 type MetricsBroadcaster struct {
 	hub           *Hub
-	metricsGetter func(ctx context.Context) (any, error)
+	msgType       string
+	metricsGetter func(ctx context.Context, sub Subscription) (any, error)
+	perDatabase   bool
 	intervalMs    int
 	logger        *slog.Logger
 }
 
-func NewMetricsBroadcaster(hub *Hub, getter func(ctx context.Context) (any, error), intervalMs int, logger *slog.Logger) *MetricsBroadcaster {
+func NewMetricsBroadcaster(hub *Hub, getter func(ctx context.Context, sub Subscription) (any, error), intervalMs int, logger *slog.Logger) *MetricsBroadcaster {
 	return &MetricsBroadcaster{
 		hub:           hub,
+		msgType:       "metrics",
+		metricsGetter: getter,
+		perDatabase:   true,
+		intervalMs:    intervalMs,
+		logger:        logger,
+	}
+}
+
+func NewClusterBroadcaster(hub *Hub, msgType string, getter func(ctx context.Context, sub Subscription) (any, error), intervalMs int, logger *slog.Logger) *MetricsBroadcaster {
+	return &MetricsBroadcaster{
+		hub:           hub,
+		msgType:       msgType,
 		metricsGetter: getter,
 		intervalMs:    intervalMs,
 		logger:        logger,
@@ -91,13 +109,36 @@ func (b *MetricsBroadcaster) run(ctx context.Context) {
 				continue
 			}
 
-			metrics, err := b.metricsGetter(ctx)
-			if err != nil {
-				b.logger.Error("failed to get metrics for broadcast", "error", err)
-				continue
+			results := make(map[Subscription]any)
+			failed := make(map[Subscription]bool)
+			for _, sub := range b.hub.Subscriptions() {
+				key := sub
+				if !b.perDatabase {
+					key = Subscription{Cluster: sub.Cluster}
+				}
+				if failed[key] {
+					continue
+				}
+
+				metrics, ok := results[key]
+				if !ok {
+					var err error
+					metrics, err = b.metricsGetter(ctx, key)
+					if err != nil {
+						b.logger.Error("failed to get metrics for broadcast",
+							"type", b.msgType,
+							"cluster", key.Cluster,
+							"database", key.Database,
+							"error", err,
+						)
+						failed[key] = true
+						continue
+					}
+					results[key] = metrics
+				}
+
+				b.hub.BroadcastToSubscription(b.msgType, sub, metrics)
 			}
-
-			b.hub.Broadcast("metrics", metrics)
 		}
 	}
 }
@@ -20,23 +20,36 @@ type Client struct {
 	conn     *websocket.Conn
 	send     chan []byte
 	clientID string
+	sub      Subscription
+	mu       sync.RWMutex
+}
+
+type Subscription struct {
+	Cluster  string `json:"cluster,omitempty"`
+	Database string `json:"database,omitempty"`
 }
 
 type Hub struct {
 	clients    map[*Client]bool
 	register   chan *Client
 	unregister chan *Client
-	broadcast  chan []byte
+	broadcast  chan outbound
 	mu         sync.RWMutex
 	logger     *slog.Logger
 }
 
+type outbound struct {
+	data     []byte
+	sub      Subscription
+	targeted bool
+}
+
 func NewHub(logger *slog.Logger) *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
-		broadcast:  make(chan []byte, 256),
+		broadcast:  make(chan outbound, 256),
 		logger:     logger,
 	}
 }
@@ -77,8 +90,11 @@ func (h *Hub) Run(ctx context.Context) {
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for client := range h.clients {
+				if message.targeted && client.Subscription() != message.sub {
+					continue
+				}
 				select {
-				case client.send <- message:
+				case client.send <- message.data:
 				default:
 					h.mu.RUnlock()
 					h.mu.Lock()
@@ -95,13 +111,31 @@ func (h *Hub) Run(ctx context.Context) {
 
 type Message struct {
 	Type      string    `json:"type"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Database  string    `json:"database,omitempty"`
 	Payload   any       `json:"payload"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
+type ClientMessage struct {
+	Type     string `json:"type"`
+	Cluster  string `json:"cluster"`
+	Database string `json:"database"`
+}
+
 func (h *Hub) Broadcast(msgType string, payload any) {
+	h.enqueue(outbound{}, msgType, payload)
+}
+
+func (h *Hub) BroadcastToSubscription(msgType string, sub Subscription, payload any) {
+	h.enqueue(outbound{sub: sub, targeted: true}, msgType, payload)
+}
+
+func (h *Hub) enqueue(out outbound, msgType string, payload any) {
 	msg := Message{
 		Type:      msgType,
+		Cluster:   out.sub.Cluster,
+		Database:  out.sub.Database,
 		Payload:   payload,
 		Timestamp: time.Now(),
 	}
@@ -111,14 +145,31 @@ func (h *Hub) Broadcast(msgType string, payload any) {
 		h.logger.Error("failed to marshal broadcast message", "error", err)
 		return
 	}
+	out.data = data
 
 	select {
-	case h.broadcast <- data:
+	case h.broadcast <- out:
 	default:
 		h.logger.Warn("broadcast channel full, dropping message")
 	}
 }
 
+func (h *Hub) Subscriptions() []Subscription {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[Subscription]bool)
+	subs := make([]Subscription, 0, 1)
+	for client := range h.clients {
+		sub := client.Subscription()
+		if !seen[sub] {
+			seen[sub] = true
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -158,9 +209,36 @@ func (c *Client) ReadPump(ctx context.Context) {
 	}()
 
 	for {
-		_, _, err := c.conn.Read(ctx)
+		msgType, data, err := c.conn.Read(ctx)
 		if err != nil {
 			return
 		}
+		if msgType != websocket.MessageText {
+			continue
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case "subscribe":
+			c.SetSubscription(Subscription{Cluster: msg.Cluster, Database: msg.Database})
+		case "unsubscribe":
+			c.SetSubscription(Subscription{})
+		}
 	}
 }
+
+func (c *Client) Subscription() Subscription {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sub
+}
+
+func (c *Client) SetSubscription(sub Subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sub = sub
+}
@@ -11,10 +11,12 @@ import {
   CollectionsListResponseSchema,
   CollectionStatsSchema,
   SchemaAnalysisSchema,
+  DocumentPageSchema,
   type CollectionsListResponse,
   type CollectionStats,
   type SchemaAnalysis,
   type IndexInfo,
+  type DocumentPage,
 } from '../types'
 import { z } from 'zod'
 
@@ -71,12 +73,12 @@ export function useCollectionIndexes(name: string) {
 export function useCollectionDocuments(name: string, limit?: number) {
   return useQuery({
     queryKey: [...QUERY_KEYS.COLLECTIONS.BY_NAME(name), 'documents'],
-    queryFn: async () => {
+    queryFn: async (): Promise<DocumentPage> => {
       const params = limit ? `?limit=${limit}` : ''
       const { data } = await apiClient.get(
         `${API_ENDPOINTS.COLLECTIONS.DOCUMENTS(name)}${params}`
       )
-      return parseApiResponse(z.any(), data)
+      return parseApiResponse(DocumentPageSchema, data)
     },
     enabled: !!name,
     staleTime: QUERY_CONFIG.STALE_TIME.COLLECTIONS,
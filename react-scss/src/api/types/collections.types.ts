@@ -32,10 +32,27 @@ export const CollectionStatsSchema = z.object({
   capped: z.boolean(),
 })
 
+export const TypeCountSchema = z.object({
+  type: z.string(),
+  count: z.number(),
+  percentage: z.number(),
+})
+
+export const LengthRangeSchema = z.object({
+  min: z.number(),
+  max: z.number(),
+  avg: z.number(),
+})
+
 export const FieldInfoSchema = z.object({
   name: z.string(),
   types: z.array(z.string()),
+  type_counts: z.array(TypeCountSchema).optional(),
   coverage: z.number(),
+  count: z.number().optional(),
+  occurrences: z.number().optional(),
+  string_length: LengthRangeSchema.optional(),
+  array_length: LengthRangeSchema.optional(),
   sample_values: z.array(z.unknown()),
 })
 
@@ -46,15 +63,42 @@ export const SchemaAnalysisSchema = z.object({
   fields: z.array(FieldInfoSchema),
 })
 
+export const IndexUsageSchema = z.object({
+  ops: z.number(),
+  since: z.string(),
+  hosts: z.number(),
+})
+
+export const IndexKeyFieldSchema = z.object({
+  field: z.string(),
+  type: z.string(),
+  direction: z.number().optional(),
+  value: z.unknown(),
+})
+
 export const IndexInfoSchema = z.object({
   name: z.string(),
-  keys: z.record(z.number()),
+  keys: z.array(IndexKeyFieldSchema),
   unique: z.boolean(),
   sparse: z.boolean(),
   background: z.boolean(),
   expire_after_seconds: z.number().optional(),
   partial_filter_expression: z.record(z.unknown()).optional(),
+  collation: z.record(z.unknown()).optional(),
+  weights: z.record(z.number()).optional(),
+  default_language: z.string().optional(),
+  language_override: z.string().optional(),
+  wildcard_projection: z.record(z.unknown()).optional(),
+  hidden: z.boolean(),
+  version: z.number().optional(),
+  text_index_version: z.number().optional(),
+  '2dsphere_index_version': z.number().optional(),
+  bits: z.number().optional(),
+  min: z.number().optional(),
+  max: z.number().optional(),
+  extra_options: z.record(z.unknown()).optional(),
   size_bytes: z.number(),
+  usage: IndexUsageSchema.optional(),
 })
 
 export const FieldStatsSchema = z.object({
@@ -75,10 +119,22 @@ export const CountResponseSchema = z.object({
 })
 
 export type CollectionInfo = z.infer<typeof CollectionInfoSchema>
+export const DocumentPageSchema = z.object({
+  collection: z.string(),
+  documents: z.array(z.record(z.unknown())),
+  next_cursor: z.string().optional(),
+  has_more: z.boolean(),
+})
+
 export type CollectionsListResponse = z.infer<typeof CollectionsListResponseSchema>
 export type CollectionStats = z.infer<typeof CollectionStatsSchema>
+export type TypeCount = z.infer<typeof TypeCountSchema>
+export type LengthRange = z.infer<typeof LengthRangeSchema>
 export type FieldInfo = z.infer<typeof FieldInfoSchema>
 export type SchemaAnalysis = z.infer<typeof SchemaAnalysisSchema>
+export type IndexKeyField = z.infer<typeof IndexKeyFieldSchema>
+export type IndexUsage = z.infer<typeof IndexUsageSchema>
 export type IndexInfo = z.infer<typeof IndexInfoSchema>
 export type FieldStats = z.infer<typeof FieldStatsSchema>
+export type DocumentPage = z.infer<typeof DocumentPageSchema>
 export type CountResponse = z.infer<typeof CountResponseSchema>
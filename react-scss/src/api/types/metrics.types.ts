@@ -59,6 +59,15 @@ export const CurrentOperationSchema = z.object({
   client: z.string(),
 })
 
+export const KPIValueSchema = z.object({
+  name: z.string(),
+  label: z.string(),
+  cluster: z.string().optional(),
+  value: z.number(),
+  sampled_at: z.string(),
+  error: z.string().optional(),
+})
+
 export const DashboardMetricsSchema = z.object({
   timestamp: z.string(),
   server: ServerMetricsSchema,
@@ -69,7 +78,7 @@ export const DashboardMetricsSchema = z.object({
   network: NetworkStatsSchema,
   active_ops: z.number(),
   current_ops: z.array(CurrentOperationSchema),
-  paid_subscribers: z.number(),
+  kpis: z.record(KPIValueSchema),
 })
 
 export const SlowQuerySchema = z.object({
@@ -144,6 +153,7 @@ export type OpCounters = z.infer<typeof OpCountersSchema>
 export type MemoryStats = z.infer<typeof MemoryStatsSchema>
 export type NetworkStats = z.infer<typeof NetworkStatsSchema>
 export type CurrentOperation = z.infer<typeof CurrentOperationSchema>
+export type KPIValue = z.infer<typeof KPIValueSchema>
 export type DashboardMetrics = z.infer<typeof DashboardMetricsSchema>
 export type SlowQuery = z.infer<typeof SlowQuerySchema>
 export type SlowQueryReport = z.infer<typeof SlowQueryReportSchema>
@@ -20,6 +20,12 @@ function formatBytes(bytes: number): string {
   return `${(bytes / (1024 * 1024 * 1024)).toFixed(2)} GB`
 }
 
+function formatIndexKeyType(type: string): string {
+  if (type === 'ascending') return 'asc'
+  if (type === 'descending') return 'desc'
+  return type
+}
+
 function StatCard({
   label,
   value,
@@ -110,9 +116,15 @@ export function Component(): React.ReactElement {
                     <tr key={field.name}>
                       <td className={styles.fieldName}>{field.name}</td>
                       <td className={styles.fieldTypes}>
-                        {field.types.map((t) => (
-                          <span key={t} className={styles.typeBadge}>{t}</span>
-                        ))}
+                        {field.type_counts
+                          ? field.type_counts.map((t) => (
+                              <span key={t.type} className={styles.typeBadge}>
+                                {t.type} {t.percentage.toFixed(0)}%
+                              </span>
+                            ))
+                          : field.types.map((t) => (
+                              <span key={t} className={styles.typeBadge}>{t}</span>
+                            ))}
                       </td>
                       <td className={styles.coverage}>{(field.coverage * 100).toFixed(0)}%</td>
                     </tr>
@@ -134,12 +146,19 @@ export function Component(): React.ReactElement {
                     <div className={styles.indexFlags}>
                       {index.unique && <span className={styles.flag}>unique</span>}
                       {index.sparse && <span className={styles.flag}>sparse</span>}
+                      {index.hidden && <span className={styles.flag}>hidden</span>}
+                      {index.expire_after_seconds !== undefined && (
+                        <span className={styles.flag}>ttl {index.expire_after_seconds}s</span>
+                      )}
+                      {index.partial_filter_expression && (
+                        <span className={styles.flag}>partial</span>
+                      )}
                     </div>
                   </div>
                   <div className={styles.indexKeys}>
-                    {Object.entries(index.keys).map(([key, dir]) => (
-                      <span key={key} className={styles.keyBadge}>
-                        {key}: {dir === 1 ? 'asc' : 'desc'}
+                    {index.keys.map((key) => (
+                      <span key={key.field} className={styles.keyBadge}>
+                        {key.field}: {formatIndexKeyType(key.type)}
                       </span>
                     ))}
                   </div>
@@ -149,11 +168,11 @@ export function Component(): React.ReactElement {
           </section>
         )}
 
-        {documents && documents.length > 0 && (
+        {documents && documents.documents.length > 0 && (
           <section className={styles.section}>
-            <h2 className={styles.sectionTitle}>Sample Documents</h2>
+            <h2 className={styles.sectionTitle}>Documents</h2>
             <div className={styles.documents}>
-              {documents.map((doc, i) => (
+              {documents.documents.map((doc, i) => (
                 <pre key={i} className={styles.document}>
                   {JSON.stringify(doc, null, 2)}
                 </pre>
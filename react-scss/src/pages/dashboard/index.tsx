@@ -89,11 +89,15 @@ export function Component(): React.ReactElement {
             value={metrics.active_ops}
             highlight
           />
-          <MetricCard
-            label="Paid Subscribers"
-            value={metrics.paid_subscribers}
-            highlight
-          />
+          {Object.values(metrics.kpis).map((kpi) => (
+            <MetricCard
+              key={kpi.name}
+              label={kpi.label}
+              value={kpi.error ? '—' : kpi.value.toLocaleString()}
+              subValue={kpi.error}
+              highlight
+            />
+          ))}
         </div>
       </section>
 